@@ -11,22 +11,41 @@ Notation Table
 
 | notation                                  | location           | summary                                                                               |
 |-------------------------------------------|--------------------|---------------------------------------------------------------------------------------|
-| :match &lt;`name` &#124; `none`>          | interface, method  | Sets the field matcher algorithm (default: `name`).                                   |
+| :match &lt;`name` &#124; `tag` _key_ &#124; `none`> | interface, method | Sets the field matcher algorithm (default: `name`).                            |
 | :style &lt;`return` &#124; `arg`>         | interface, method  | Sets the style of the assignee variable input/output (default: `return`).             |
 | :recv &lt;_var_>                          | method             | Specifies the source value as a receiver of the generated function.                   |
 | :reverse                                  | 	method            | Reverses the copy direction. Might be useful with receiver form.                      |
 | :case	                                    | interface, method  | Sets case-sensitive for name match (default).                                         |
 | :case:off	                                | interface, method  | Sets case-insensitive for name match.                                                 |
+| :normalize &lt;_normalizer_>...          | interface, method  | Normalizes field names before name match. _normalizer_ is acronym, snake or Pfx*Sfx.  |
+| :normalize:off                            | interface, method  | Compares field names as they are (default).                                           |
 | :getter	                                  | interface, method  | Includes getters for name match.                                                      |
 | :getter:off	                              | interface, method  | Excludes getters for name match (default).                                            |
 | :stringer                                 | 	interface, method | Calls String() if appropriate in name match.                                          |
 | :stringer:off                             | 	interface, method | Calls String() if appropriate in name match (default).                                |
-| :typecast	                                | interface, method	 | Allows type casting if appropriate in name match.                                     |
+| :text                                     | interface, method  | Converts between string and types with MarshalText, UnmarshalText or Parse&lt;Type>.  |
+| :text:off                                 | interface, method  | Suppresses text conversion (default).                                                 |
+| :typecast [`checked`]                     | interface, method	 | Allows type casting if appropriate in name match. `checked` checks numeric overflows.  |
 | :typecast:off                             | 	interface, method | Suppresses type casting if appropriate in name match (default).                       |
+| :nilsafe                                  | interface, method  | Guards pointers on the path of `:map`/`:conv` sources against nil.                    |
+| :nilsafe:off                              | interface, method  | Accesses the sources of `:map`/`:conv` without nil guards (default).                  |
+| :ptrcast [_nil policy_]                   | interface, method  | Converts between pointers and values. _nil policy_ is one of zero, skip or error.     |
+| :ptrcast:off                              | interface, method  | Suppresses conversion between pointers and values (default).                          |
+| :merge                                    | interface, method  | Matches the fields in the additional struct arguments, too. Earlier arguments win.   |
+| :merge:off                                | interface, method  | Matches the fields in the first argument only (default).                              |
 | :skip &lt;_dst field pattern_>            | method             | Marks the destination field to skip copying. Regex is allowed in /…/ syntax.          |
 | :map &lt;_src_> &lt;_dst field_>          | method             | the pair as assign source and destination.                                            |
 | :conv &lt;_func_> &lt;_src_> [_to field_] | method             | Converts the source value by the converter and assigns its result to the destination. |
+| :conv:type &lt;_func_> &lt;_src type_> [_dst type_] | interface, method | Converts every value of the source type by the converter.               |
+| :conv:with &lt;_func_> &lt;_dst field_>   | method             | Assigns the result of the converter that takes the entire source to the destination. |
+| :enum &lt;_src type_> &lt;_dst type_> [_src pattern_ [_dst pattern_]] | interface, method | Maps the constants of the source type to the destination type by name. |
+| :variant &lt;_func_>                     | interface, method  | Converts an interface value by a type switch that calls _func_ for its argument type. |
+| :builtin &lt;_group_>[,_group_...]      | interface, method  | Applies the bundled converters of the groups: time, strconv, bytes or uuid.          |
+| :builtin:off                              | interface, method  | Applies no bundled converters (default).                                              |
+| :flatten &lt;_src field_> [_prefix_]     | method             | Matches the fields of the nested source struct with the prefixed destination fields. |
+| :unflatten &lt;_dst field_> [_prefix_]   | method             | Builds the nested destination struct from the prefixed source fields.                 |
 | :literal &lt;_dst_> &lt;_literal_>        | method             | Assigns the literal expression to the destination.                                    |
+| :fallback &lt;_dst_> &lt;_literal_>       | method             | Assigns the literal expression to the destination if `:nilsafe` finds a nil pointer.  |
 | :preprocess &lt;_func_>                   | method             | Calls the function at the beginning of the convergen func.                            |
 | :postprocess &lt;_func_>                  | method             | Calls the function at the end of the convergen function.                              |
 
@@ -64,18 +83,88 @@ You can then generate code by calling:
 $ convergen any-codegen-defined-code.go
 ```
 
+Or, for every setup file in packages, i.e. a file that has the `convergen` build tag and
+convergen interfaces:
+
+```shell
+$ convergen ./...
+$ convergen ./domain ./storage/...
+```
+
+The packages are type-checked once in a single load, and each setup file gets its own
+`<basename>.gen.go`. Previously generated files next to setup files are ignored while loading.
+
+To verify in CI that the generated files are not stale, add `-check`. It writes nothing, prints
+a unified diff for every generated file that differs from what convergen would generate now,
+and exits with a non-zero status if any of them does:
+
+```shell
+$ convergen -check ./...
+```
+
+### Diagnostics
+
+Errors and warnings are written to stderr with their positions in the setup files, a severity,
+a stable code such as `no-assignment` or `unknown-notation`, and a suggested fix if any:
+
+```shell
+setup.go:13:2: warning: no assignment for dst.ID [uint64] (no-assignment)
+	fix: add ":skip ID" to the method to leave it unassigned
+```
+
+Convergen checks every interface and method even after an error, so a run reports all the
+errors at once. It writes no file for a setup file that has an error.
+
+For editors and code-review bots, `-diag json` writes them as a JSON array, and `-diag sarif`
+writes a [SARIF](https://sarifweb.azurewebsites.net/) 2.1.0 log:
+
+```shell
+$ convergen -diag sarif ./... 2> convergen.sarif
+```
+
+### Use as a library
+
+To run Convergen in-process, e.g. from another code generator, call `convergen.Generate`
+in `github.com/reedom/convergen/v8/pkg/convergen`. It takes a setup file path or package
+patterns, optionally with in-memory file contents that take the place of the files on disk,
+and returns the generated code and the diagnostics without writing any file or exiting the
+process. It is safe to call concurrently.
+
+```go
+result, err := convergen.Generate(convergen.Request{
+    Patterns: []string{"./..."},
+    Overlay:  map[string][]byte{"/abs/path/to/setup.go": unsavedContent},
+})
+for _, d := range result.Diagnostics {
+    fmt.Println(d.Pos, d.Severity, d.Code, d.Message, d.Fix)
+}
+if err != nil {
+    return err
+}
+for _, file := range result.Files {
+    // file.Input is the setup file, file.Output is "<basename>.gen.go" and file.Code is the code.
+}
+```
+
 The CLI help shows:
 
 ```shell
 Usage: convergen [flags] <input path>
+       convergen [flags] <package pattern>...
 
 By default, the generated code is written to <input path>.gen.go
+With package patterns such as ./..., every file that has the convergen build tag
+and convergen interfaces in the packages is processed, and <file>.gen.go is written for each.
 
 Flags:
+  -check
+        Check the output files are up to date without writing, and print the differences.
+  -diag string
+        Write errors and warnings to STDERR in the format: text, json or sarif. (default "text")
   -dry
         Perform a dry run without writing files.
   -log
-        Write log messages to <output path>.log.
+        Write log messages to <output path>.log, or convergen.log with package patterns.
   -out string
         Set the output file path.
   -print
@@ -5,13 +5,13 @@ go 1.24.0
 require (
 	github.com/google/go-cmp v0.6.0
 	github.com/matoous/go-nanoid v1.5.1
+	github.com/pmezard/go-difflib v1.0.0
 	github.com/stretchr/testify v1.8.0
 	golang.org/x/tools v0.37.0
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
-	github.com/pmezard/go-difflib v1.0.0 // indirect
 	golang.org/x/mod v0.28.0 // indirect
 	golang.org/x/sync v0.17.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
 	"os"
 
@@ -10,13 +12,18 @@ import (
 
 func main() {
 	var conf config.Config
-	if err := conf.ParseArgs(); err != nil {
+	if err := conf.ParseArgs(os.Args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
 		_, _ = fmt.Fprintln(os.Stderr, err.Error())
 		os.Exit(1)
 	}
 
 	if err := runner.Run(conf); err != nil {
-		_, _ = fmt.Fprintln(os.Stderr, err.Error())
+		if !errors.Is(err, runner.ErrReported) {
+			_, _ = fmt.Fprintln(os.Stderr, err.Error())
+		}
 		os.Exit(1)
 	}
 }
@@ -1,11 +1,15 @@
 package builder
 
 import (
+	"errors"
 	"fmt"
 	"go/ast"
 	"go/token"
 	"go/types"
+	"path"
+	"slices"
 	"strconv"
+	"strings"
 
 	bmodel "github.com/reedom/convergen/v8/pkg/builder/model"
 	gmodel "github.com/reedom/convergen/v8/pkg/generator/model"
@@ -22,6 +26,7 @@ type assignmentBuilder struct {
 	fset    *token.FileSet    // The fileset the assignment belongs to.
 	pkg     *packages.Package // The package the assignment belongs to.
 	imports util.ImportNames  // The import names to use in the generated code.
+	logger  *logger.Logger    // The logger to report the errors and warnings to.
 
 	methodPos         token.Pos        // The position of the method in the source code.
 	opts              option.Options   // The options to use when generating the code.
@@ -30,6 +35,11 @@ type assignmentBuilder struct {
 	additionalArgVars []gmodel.Var     // The additional arguments to use in the assignment.
 	funcName          string           // The name of the method being generated.
 	copiers           []*bmodel.Copier // The list of copiers used in the generated code.
+	funcBuilder       *FunctionBuilder // The function builder that owns the copier functions.
+	additionalArgs    []bmodel.Node    // The root nodes of the additional arguments.
+	retError          bool             // Whether the function being generated returns an error.
+
+	flattenNested map[*option.FlattenRule]bmodel.Node // The nested structs in the source that the valid flatten rules refer.
 }
 
 // newAssignmentBuilder creates a new assignmentBuilder instance.
@@ -44,12 +54,15 @@ func newAssignmentBuilder(
 		fset:              p.fset,
 		pkg:               p.pkg,
 		imports:           p.imports,
+		logger:            p.logger,
 		methodPos:         m.Method.Pos(),
 		opts:              m.Opts,
 		lhsVar:            lhsVar,
 		rhsVar:            rhsVar,
 		additionalArgVars: additionalArgs,
 		funcName:          m.Name(),
+		funcBuilder:       p,
+		retError:          m.RetError(),
 	}
 }
 
@@ -71,7 +84,43 @@ func (b *assignmentBuilder) build(lhs, rhs *types.Var, additionalArgs []*types.V
 	for i, arg := range additionalArgs {
 		rootAdditionalArgs[i] = bmodel.NewRootNode(b.additionalArgVars[i].Name, arg.Type())
 	}
-	return b.dispatch(rootLHS, rootRHS, rootAdditionalArgs)
+	b.additionalArgs = rootAdditionalArgs
+
+	// Report the invalid rules once for the method, and go on without them to report the other errors, too.
+	ruleErr := b.resolveFlattenRules(rootLHS, rootRHS)
+	assignments, err := b.dispatch(rootLHS, rootRHS, rootAdditionalArgs)
+	if err := errors.Join(ruleErr, err); err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
+
+// resolveFlattenRules resolves the nested structs that the flatten and unflatten rules refer,
+// and reports the rules whose nested fields are not structs.
+// The invalid rules are dropped so that createWithFlatten and createWithUnflatten only match fields.
+func (b *assignmentBuilder) resolveFlattenRules(lhs, rhs bmodel.Node) error {
+	var errs []error
+	b.flattenNested = make(map[*option.FlattenRule]bmodel.Node)
+	for _, rule := range b.opts.Flatten {
+		nested, ok := b.resolveExpr(rule.Nested(), rhs)
+		if !ok || !util.IsStructType(util.DerefPtr(nested.ExprType())) {
+			errs = append(errs, b.logger.ErrorAt(b.fset.Position(rule.Pos()), logger.CodeNotFound, "%v.%v is not a struct field", rhs.AssignExpr(), rule.Nested().Pattern()))
+			continue
+		}
+		b.flattenNested[rule] = nested
+	}
+
+	unflatten := make([]*option.FlattenRule, 0, len(b.opts.Unflatten))
+	for _, rule := range b.opts.Unflatten {
+		nested, ok := b.resolveExpr(rule.Nested(), lhs)
+		if !ok || !util.IsStructType(util.DerefPtr(nested.ExprType())) {
+			errs = append(errs, b.logger.ErrorAt(b.fset.Position(rule.Pos()), logger.CodeNotFound, "%v.%v is not a struct field", lhs.AssignExpr(), rule.Nested().Pattern()))
+			continue
+		}
+		unflatten = append(unflatten, rule)
+	}
+	b.opts.Unflatten = unflatten
+	return errors.Join(errs...)
 }
 
 // dispatch decides what type of assignment should be generated.
@@ -82,40 +131,52 @@ func (b *assignmentBuilder) dispatch(lhs, rhs bmodel.Node, additionalArgs []bmod
 		return b.structToStruct(lhs, rhs, additionalArgs)
 	}
 
-	logger.Warnf("%v: no assignment %T to %T", b.fset.Position(b.methodPos), rhs.ExprType(), lhs.ExprType())
+	b.logger.WarnAt(b.fset.Position(b.methodPos), logger.CodeUnsupportedConversion, "no assignment %T to %T", rhs.ExprType(), lhs.ExprType())
 	return []gmodel.Assignment{gmodel.NoMatchField{LHS: lhs.AssignExpr()}}, nil
 }
 
 // structToStruct generates code for a struct-to-struct assignment.
 func (b *assignmentBuilder) structToStruct(lhsStruct, rhsStruct bmodel.Node, additionalArgs []bmodel.Node) ([]gmodel.Assignment, error) {
-	var err error
+	if b.opts.Rule == gmodel.MatchRuleTag {
+		if err := b.validateTagValues(lhsStruct); err != nil {
+			return nil, err
+		}
+		if err := b.validateTagValues(rhsStruct); err != nil {
+			return nil, err
+		}
+	}
+
+	// Match all the fields even if some of them fail, to report every error at once.
+	var errs []error
 	var assignments []gmodel.Assignment
 	bmodel.IterateStructFields(lhsStruct, func(lhsField bmodel.Node) (done bool) {
 		if !b.isStructFieldAccessible(lhsStruct, lhsField.ObjName()) {
 			return
 		}
 
-		var a gmodel.Assignment
-		a, err = b.matchStructFieldAndStruct(lhsField, rhsStruct, additionalArgs)
-		if err == nil && a != nil {
-			assignments = append(assignments, a)
+		a, err := b.matchStructFieldAndStruct(lhsField, rhsStruct, additionalArgs)
+		if err != nil {
+			errs = append(errs, err)
+		} else if a != nil {
+			assignments = appendGuarded(assignments, a)
 		}
 		return
 	})
-	return assignments, err
+	return assignments, errors.Join(errs...)
 }
 
 // matchStructFieldAndStruct matches a field in a struct with another struct
 // and returns an assignment. It checks if the field should be skipped and if
-// not, tries to match the field with a converter, name mapper or literal
-// setter. If none of these match, it falls back to the
-// structFieldAndStructGettersAndFields method.
+// not, tries to match the field with a converter, name mapper, literal
+// setter or flatten rule. If none of these match, it falls back to the
+// structFieldAndStructGettersAndFields method, or the mergeFieldFromStructs method
+// for a root-level field in the merge mode.
 func (b *assignmentBuilder) matchStructFieldAndStruct(
 	lhs, rhs bmodel.Node,
 	additionalArgs []bmodel.Node,
 ) (gmodel.Assignment, error) {
 	if b.opts.ShouldSkip(lhs.MatcherExpr()) {
-		logger.Printf("%v: skip %v", b.fset.Position(b.methodPos), lhs.AssignExpr())
+		b.logger.Printf("%v: skip %v", b.fset.Position(b.methodPos), lhs.AssignExpr())
 		return gmodel.SkipField{LHS: lhs.AssignExpr()}, nil
 	}
 
@@ -125,6 +186,12 @@ func (b *assignmentBuilder) matchStructFieldAndStruct(
 			return b.createWithConverter(lhs, rhs, converter)
 		}
 	}
+	for _, converter := range b.opts.StructConverters {
+		if converter.Dst().Match(lhs.MatcherExpr(), true) {
+			// If there are more than one converter exist for the lhs, the first one wins.
+			return b.createWithStructConverter(lhs, rhs, converter)
+		}
+	}
 	for _, mapper := range b.opts.NameMapper {
 		if mapper.Dst().Match(lhs.MatcherExpr(), true) {
 			// If there are more than one mapper exist for the lhs, the first one wins.
@@ -144,7 +211,21 @@ func (b *assignmentBuilder) matchStructFieldAndStruct(
 			return gmodel.SimpleField{LHS: lhs.AssignExpr(), RHS: setter.Literal()}, nil
 		}
 	}
+	for _, rule := range b.opts.Unflatten {
+		if rule.Nested().Match(lhs.MatcherExpr(), true) {
+			return b.createWithUnflatten(lhs, rhs, rule)
+		}
+	}
+	for _, rule := range b.opts.Flatten {
+		a, err := b.createWithFlatten(lhs, rule)
+		if a != nil || err != nil {
+			return a, err
+		}
+	}
 
+	if b.opts.Merge && rhs.Parent() == nil && 0 < len(additionalArgs) {
+		return b.mergeFieldFromStructs(lhs, mergeSources(rhs, additionalArgs))
+	}
 	return b.structFieldAndStructGettersAndFields(lhs, rhs)
 }
 
@@ -154,112 +235,458 @@ func (b *assignmentBuilder) matchStructFieldAndStruct(
 // If no match is found, returns a NoMatchField or SkipField if the field is to be skipped
 // based on the options set in the AssignmentBuilder.
 func (b *assignmentBuilder) structFieldAndStructGettersAndFields(lhs bmodel.Node, rhsStruct bmodel.Node) (gmodel.Assignment, error) {
+	a, matched, err := b.lookupFieldInStruct(lhs, rhsStruct)
+	if matched || err != nil {
+		return a, err
+	}
+
+	b.warnNoAssignment(b.fset.Position(b.methodPos), lhs)
+	return gmodel.NoMatchField{LHS: lhs.AssignExpr()}, nil
+}
+
+// lookupFieldInStruct looks up a getter or a field in rhsStruct that matches lhs and creates an assignment.
+// matched reports whether the lookup has finished; the assignment may be nil even if matched is true,
+// when lhs is a nested struct that has nothing to copy.
+func (b *assignmentBuilder) lookupFieldInStruct(lhs bmodel.Node, rhsStruct bmodel.Node) (a gmodel.Assignment, matched bool, err error) {
 	opts := b.opts
 	methodPosStr := b.fset.Position(b.methodPos)
 	lhsExpr := lhs.AssignExpr()
 
-	logger.Printf("%v: lookup assignment for %v = %v.*", methodPosStr, lhsExpr, rhsStruct.AssignExpr())
+	b.logger.Printf("%v: lookup assignment for %v = %v.*", methodPosStr, lhsExpr, rhsStruct.AssignExpr())
+
+	if 0 < len(opts.Normalizers) && opts.Rule == gmodel.MatchRuleName {
+		if err = b.validateNormalizedMatch(lhs, rhsStruct); err != nil {
+			return
+		}
+	}
 
-	var a gmodel.Assignment
-	var err error
 	// To prevent logging "no assignment for d.NestedData"…
 	nested := false
 
 	handler := func(rhs bmodel.Node) (done bool) {
 		if !b.isStructFieldAccessible(rhsStruct, rhs.ObjName()) ||
-			!opts.CompareFieldName(lhs.ObjName(), rhs.ObjName()) {
+			!b.matchFieldName(lhs, rhs) {
 			return
 		}
 
-		if util.IsSliceType(lhs.ExprType()) && util.IsSliceType(rhs.ExprType()) {
-			a, err = b.sliceToSlice(lhs, rhs)
-			if a != nil || err != nil {
-				logger.Printf("%v: assignment found: sliceCopy(%v, %v)", methodPosStr, lhsExpr, rhs.AssignExpr())
-				return true
-			}
+		a, nested, err = b.assignField(lhs, rhs)
+		return true
+	}
+
+	// Getters have no struct tags so that they never match in the tag rule.
+	if opts.Getter && opts.Rule != gmodel.MatchRuleTag {
+		bmodel.IterateStructMethods(rhsStruct, handler)
+		if a != nil || err != nil {
+			return a, true, err
 		}
+	}
 
-		if c, ok := b.castNode(lhs.ExprType(), rhs); ok {
-			rhsExpr := c.AssignExpr()
-			logger.Printf("%v: assignment found: %v = %v", methodPosStr, lhsExpr, rhsExpr)
-			a = gmodel.SimpleField{LHS: lhsExpr, RHS: rhsExpr, Error: c.ReturnsError()}
-			return true
+	if opts.Rule == gmodel.MatchRuleName || opts.Rule == gmodel.MatchRuleTag {
+		bmodel.IterateStructFields(rhsStruct, handler)
+		if a != nil || err != nil || nested {
+			return a, true, err
 		}
+	}
+	return nil, false, nil
+}
 
-		if util.IsStructType(lhs.ExprType()) &&
-			util.IsStructType(rhs.ExprType()) {
-			nested = true
-			nestStruct := gmodel.NestStruct{}
-			if util.IsPtr(lhs.ExprType()) {
-				nestStruct.InitExpr = fmt.Sprintf("%v = %v{}", lhs.AssignExpr(), b.imports.TypeName(lhs.ExprType()))
-			}
-			if rhs.ObjNullable() {
-				nestStruct.NullCheckExpr = rhs.NullCheckExpr()
-			}
-			nestStruct.Contents, err = b.structToStruct(lhs, rhs, nil)
-			if err == nil && 0 < len(nestStruct.Contents) {
-				a = nestStruct
+// mergeFieldFromStructs creates an assignment for lhs from the first struct in rhsStructs that has
+// a matching getter or field. The other structs that have a getter or field with the matching name
+// are reported as conflicts.
+func (b *assignmentBuilder) mergeFieldFromStructs(lhs bmodel.Node, rhsStructs []bmodel.Node) (gmodel.Assignment, error) {
+	methodPosStr := b.fset.Position(b.methodPos)
+
+	var ret gmodel.Assignment
+	var from bmodel.Node
+	for _, rhsStruct := range rhsStructs {
+		if from != nil {
+			if b.hasMatchingName(lhs, rhsStruct) {
+				b.logger.WarnAt(methodPosStr, logger.CodeAmbiguousMatch, "%v is found in both %v and %v; %v takes precedence", lhs.AssignExpr(), from.AssignExpr(), rhsStruct.AssignExpr(), from.AssignExpr())
 			}
+			continue
 		}
-		return true
+
+		a, matched, err := b.lookupFieldInStruct(lhs, rhsStruct)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			ret, from = a, rhsStruct
+		}
+	}
+	if from != nil {
+		return ret, nil
 	}
 
-	if opts.Getter {
+	b.warnNoAssignment(methodPosStr, lhs)
+	return gmodel.NoMatchField{LHS: lhs.AssignExpr()}, nil
+}
+
+// hasMatchingName reports whether rhsStruct has a getter or field that matches lhs by the matching rule.
+func (b *assignmentBuilder) hasMatchingName(lhs, rhsStruct bmodel.Node) bool {
+	found := false
+	handler := func(rhs bmodel.Node) (done bool) {
+		found = b.isStructFieldAccessible(rhsStruct, rhs.ObjName()) && b.matchFieldName(lhs, rhs)
+		return found
+	}
+	if b.opts.Getter && b.opts.Rule != gmodel.MatchRuleTag {
 		bmodel.IterateStructMethods(rhsStruct, handler)
+	}
+	if !found && (b.opts.Rule == gmodel.MatchRuleName || b.opts.Rule == gmodel.MatchRuleTag) {
+		bmodel.IterateStructFields(rhsStruct, handler)
+	}
+	return found
+}
+
+// mergeSources returns rhs followed by the additional arguments of struct types,
+// in the order of precedence for ":merge".
+func mergeSources(rhs bmodel.Node, additionalArgs []bmodel.Node) []bmodel.Node {
+	sources := []bmodel.Node{rhs}
+	for _, arg := range additionalArgs {
+		if util.IsStructType(util.DerefPtr(arg.ExprType())) {
+			sources = append(sources, arg)
+		}
+	}
+	return sources
+}
+
+// assignField creates an assignment between a pair of fields or getters that have been matched.
+// nested reports whether they are structs that are copied field by field; if so, a nil assignment
+// means there is nothing to copy rather than no match.
+func (b *assignmentBuilder) assignField(lhs, rhs bmodel.Node) (a gmodel.Assignment, nested bool, err error) {
+	methodPosStr := b.fset.Position(b.methodPos)
+	lhsExpr := lhs.AssignExpr()
+
+	if util.IsSliceType(lhs.ExprType()) && util.IsSliceType(rhs.ExprType()) {
+		a, err = b.sliceToSlice(lhs, rhs)
 		if a != nil || err != nil {
-			return a, err
+			b.logger.Printf("%v: assignment found: sliceCopy(%v, %v)", methodPosStr, lhsExpr, rhs.AssignExpr())
+			return
 		}
 	}
 
-	if opts.Rule == gmodel.MatchRuleName {
-		bmodel.IterateStructFields(rhsStruct, handler)
-		if a != nil || err != nil || nested {
-			return a, err
+	if util.IsMapType(lhs.ExprType()) && util.IsMapType(rhs.ExprType()) {
+		a, err = b.mapToMap(lhs, rhs)
+		if a != nil || err != nil {
+			b.logger.Printf("%v: assignment found: mapCopy(%v, %v)", methodPosStr, lhsExpr, rhs.AssignExpr())
+			return
 		}
 	}
 
-	logger.Warnf("%v: no assignment for %v [%v]", methodPosStr, lhsExpr, b.imports.TypeName(lhs.ExprType()))
-	return gmodel.NoMatchField{LHS: lhsExpr}, nil
+	if c, ok := b.castNode(lhs.ExprType(), rhs); ok {
+		rhsExpr := c.AssignExpr()
+		b.logger.Printf("%v: assignment found: %v = %v", methodPosStr, lhsExpr, rhsExpr)
+		a = gmodel.SimpleField{LHS: lhsExpr, RHS: rhsExpr, Error: c.ReturnsError()}
+		return
+	}
+
+	if util.IsArrayType(lhs.ExprType()) || util.IsArrayType(rhs.ExprType()) {
+		a, err = b.arrayAssignment(lhs, rhs)
+		if a != nil || err != nil {
+			b.logger.Printf("%v: assignment found: arrayCopy(%v, %v)", methodPosStr, lhsExpr, rhs.AssignExpr())
+			return
+		}
+	}
+
+	if a = b.nullableAssignment(lhs, rhs); a != nil {
+		b.logger.Printf("%v: assignment found: %v = %v (nullable)", methodPosStr, lhsExpr, rhs.AssignExpr())
+		return
+	}
+
+	if util.IsPtr(rhs.ExprType()) {
+		a, err = b.derefAssignment(lhs, rhs)
+		if a != nil || err != nil {
+			b.logger.Printf("%v: assignment found: %v = *%v", methodPosStr, lhsExpr, rhs.AssignExpr())
+			return
+		}
+	}
+
+	if b.isRecursiveField(lhs, rhs) {
+		var c bmodel.Node
+		var ok bool
+		c, ok, err = b.copierNode(lhs.ExprType(), rhs)
+		if ok {
+			rhsExpr := c.AssignExpr()
+			b.logger.Printf("%v: assignment found: %v = %v", methodPosStr, lhsExpr, rhsExpr)
+			a = gmodel.SimpleField{LHS: lhsExpr, RHS: rhsExpr, Error: c.ReturnsError()}
+		}
+		return
+	}
+
+	if util.IsStructType(lhs.ExprType()) &&
+		util.IsStructType(rhs.ExprType()) {
+		nested = true
+		nestStruct := gmodel.NestStruct{InitExpr: b.nestInitExpr(lhs)}
+		if rhs.ObjNullable() {
+			nestStruct.NullCheckExpr = rhs.NullCheckExpr()
+		}
+		nestStruct.Contents, err = b.structToStruct(lhs, rhs, nil)
+		if err == nil && 0 < len(nestStruct.Contents) {
+			a = nestStruct
+		}
+	}
+	return
+}
+
+// nestInitExpr returns a statement that initializes the lhs nested struct if it is a pointer,
+// e.g. "dst.Address = &model.Address{}". Otherwise, it returns an empty string.
+func (b *assignmentBuilder) nestInitExpr(lhs bmodel.Node) string {
+	if !util.IsPtr(lhs.ExprType()) {
+		return ""
+	}
+	return fmt.Sprintf("%v = &%v{}", lhs.AssignExpr(), b.imports.TypeName(util.DerefPtr(lhs.ExprType())))
+}
+
+// matchFieldName reports whether the lhs field and the rhs field or getter are a pair.
+// In the tag rule, they are paired by their tag values of opts.TagKey; a field without
+// the tag never matches. Otherwise, they are paired by their names.
+func (b *assignmentBuilder) matchFieldName(lhs, rhs bmodel.Node) bool {
+	if b.opts.Rule != gmodel.MatchRuleTag {
+		return b.opts.CompareFieldName(lhs.ObjName(), rhs.ObjName())
+	}
+
+	lhsField, ok := lhs.(bmodel.StructFieldNode)
+	if !ok {
+		return false
+	}
+	rhsField, ok := rhs.(bmodel.StructFieldNode)
+	if !ok {
+		return false
+	}
+	lhsTag, ok := lhsField.TagValue(b.opts.TagKey)
+	if !ok {
+		return false
+	}
+	rhsTag, ok := rhsField.TagValue(b.opts.TagKey)
+	return ok && lhsTag == rhsTag
+}
+
+// validateTagValues returns an error if more than one field in the struct have the same tag value
+// of opts.TagKey, since it makes the tag match ambiguous.
+func (b *assignmentBuilder) validateTagValues(structNode bmodel.Node) error {
+	var err error
+	names := make(map[string]string)
+	bmodel.IterateStructFields(structNode, func(node bmodel.Node) (done bool) {
+		field, ok := node.(bmodel.StructFieldNode)
+		if !ok {
+			return
+		}
+		tag, ok := field.TagValue(b.opts.TagKey)
+		if !ok {
+			return
+		}
+		if name, dup := names[tag]; dup {
+			err = b.logger.ErrorAt(b.fset.Position(b.methodPos), logger.CodeAmbiguousMatch, `%v has duplicate tag values %v:"%v" in %v and %v`, b.imports.TypeName(util.DerefPtr(structNode.ExprType())),
+				b.opts.TagKey, tag, name, field.ObjName())
+			return true
+		}
+		names[tag] = field.ObjName()
+		return
+	})
+	return err
+}
+
+// validateNormalizedMatch returns an error if the lhs field matches more than one field in rhsStruct,
+// which happens when their names are normalized into the same one.
+func (b *assignmentBuilder) validateNormalizedMatch(lhs, rhsStruct bmodel.Node) error {
+	var names []string
+	bmodel.IterateStructFields(rhsStruct, func(rhs bmodel.Node) (done bool) {
+		if b.isStructFieldAccessible(rhsStruct, rhs.ObjName()) && b.matchFieldName(lhs, rhs) {
+			names = append(names, rhs.ObjName())
+		}
+		return
+	})
+	if 1 < len(names) {
+		return b.logger.ErrorAt(b.fset.Position(b.methodPos), logger.CodeAmbiguousMatch, "%v matches more than one field %v in %v by the normalized names", lhs.AssignExpr(), strings.Join(names, ", "),
+			b.imports.TypeName(util.DerefPtr(rhsStruct.ExprType())))
+	}
+	return nil
+}
+
+// isRecursiveField reports whether the pair of struct fields refers one of their containers,
+// e.g. "Next *Node" in "Node". Such a pair is converted by a copier function that calls itself,
+// since nesting the assignments inline never ends.
+func (b *assignmentBuilder) isRecursiveField(lhs, rhs bmodel.Node) bool {
+	if !util.IsStructType(util.DerefPtr(lhs.ExprType())) || !util.IsStructType(util.DerefPtr(rhs.ExprType())) {
+		return false
+	}
+	return bmodel.IsRecursive(lhs.Parent(), lhs.ExprType()) || bmodel.IsRecursive(rhs.Parent(), rhs.ExprType())
 }
 
 // createWithConverter creates an assignment using the given field converter.
 // It resolves the source field, applies the converter, and creates an assignment from the result.
+// If the converter doesn't accept the source field as a whole, it is applied to each element of
+// the source slice, array, map or pointer.
 func (b *assignmentBuilder) createWithConverter(lhs, rhs bmodel.Node, converter *option.FieldConverter) (gmodel.Assignment, error) {
-	converterNode := func() bmodel.Node {
-		root := rhs
-		for ; root.Parent() != nil; root = root.Parent() {
+	lhsExpr := lhs.AssignExpr()
+	posStr := b.fset.Position(converter.Pos())
+
+	root := rhs
+	for ; root.Parent() != nil; root = root.Parent() {
+	}
+
+	if rhsNode, ok := b.resolveExpr(converter.Src(), root); ok {
+		if converterNode, ok := b.converterNode(lhs.ExprType(), rhsNode, converter); ok {
+			rhsExpr := converterNode.AssignExpr()
+			b.logger.Printf("%v: assignment found: %v = %v, err", posStr, lhsExpr, rhsExpr)
+			a := gmodel.SimpleField{LHS: lhsExpr, RHS: rhsExpr, Error: converter.RetError()}
+			return b.guardNilHops(lhs, converterNode, a), nil
+		}
+		if a, ok := b.liftConverter(lhs, rhsNode, converter); ok {
+			b.logger.Printf("%v: assignment found: %v = %v(each of %v)", posStr, lhsExpr, converter.Converter(), rhsNode.AssignExpr())
+			return b.guardNilHops(lhs, rhsNode, a), nil
 		}
+	}
+
+	b.warnNoAssignment(posStr, lhs)
+	return gmodel.NoMatchField{LHS: lhsExpr}, nil
+}
 
-		rhsNode, ok := b.resolveExpr(converter.Src(), root)
+// converterNode returns a node that applies the converter to rhs and casts the result into lhsType.
+func (b *assignmentBuilder) converterNode(lhsType types.Type, rhs bmodel.Node, converter *option.FieldConverter) (bmodel.Node, bool) {
+	argNode, ok := b.castNode(converter.ArgType(), rhs)
+	if !ok {
+		if !util.IsPtr(converter.ArgType()) {
+			return nil, false
+		}
+		argNode, ok = b.castNode(util.DerefPtr(converter.ArgType()), rhs)
 		if !ok {
-			return nil
+			return nil, false
 		}
+	}
+	return b.castNode(lhsType, bmodel.NewConverterNode(argNode, converter))
+}
+
+// liftConverter creates an assignment that applies the converter to each element of rhs
+// and stores the results into lhs. Both of them must be slices, arrays of the same length,
+// maps or pointers. The keys of maps are converted by the rules of castNode.
+func (b *assignmentBuilder) liftConverter(lhs, rhs bmodel.Node, converter *option.FieldConverter) (gmodel.Assignment, bool) {
+	lhsExpr := lhs.AssignExpr()
+	rhsExpr := rhs.AssignExpr()
 
-		argNode, ok := b.castNode(converter.ArgType(), rhsNode)
+	switch lhsType := lhs.ExprType().Underlying().(type) {
+	case *types.Slice:
+		rhsType, ok := rhs.ExprType().Underlying().(*types.Slice)
 		if !ok {
-			if !util.IsPtr(converter.ArgType()) {
-				return nil
-			}
-			argNode, ok = b.castNode(util.DerefPtr(converter.ArgType()), rhsNode)
-			if !ok {
-				return nil
-			}
+			return nil, false
 		}
-		convNode := bmodel.NewConverterNode(argNode, converter)
-		casted, _ := b.castNode(lhs.ExprType(), convNode)
-		return casted
-	}()
+		elem, ok := b.converterNode(lhsType.Elem(), bmodel.NewScalarNode(nil, "e", rhsType.Elem()), converter)
+		if !ok {
+			return nil, false
+		}
+		return gmodel.SliceConvAssignment{
+			LHS:   lhsExpr,
+			RHS:   rhsExpr,
+			Typ:   "[]" + b.imports.TypeName(lhsType.Elem()),
+			Elem:  elem.AssignExpr(),
+			Error: elem.ReturnsError(),
+		}, true
+	case *types.Array:
+		rhsType, ok := rhs.ExprType().Underlying().(*types.Array)
+		if !ok || lhsType.Len() != rhsType.Len() {
+			return nil, false
+		}
+		elem, ok := b.converterNode(lhsType.Elem(), bmodel.NewScalarNode(nil, "e", rhsType.Elem()), converter)
+		if !ok {
+			return nil, false
+		}
+		return gmodel.ArrayConvAssignment{
+			LHS:   lhsExpr,
+			RHS:   rhsExpr,
+			Elem:  elem.AssignExpr(),
+			Error: elem.ReturnsError(),
+		}, true
+	case *types.Map:
+		rhsType, ok := rhs.ExprType().Underlying().(*types.Map)
+		if !ok {
+			return nil, false
+		}
+		key, ok := b.castElemNode(lhsType.Key(), bmodel.NewScalarNode(nil, "k", rhsType.Key()), "key of "+rhsExpr)
+		if !ok {
+			return nil, false
+		}
+		value, ok := b.converterNode(lhsType.Elem(), bmodel.NewScalarNode(nil, "v", rhsType.Elem()), converter)
+		if !ok {
+			return nil, false
+		}
+		return gmodel.MapAssignment{
+			LHS:   lhsExpr,
+			RHS:   rhsExpr,
+			Typ:   b.imports.TypeName(lhs.ExprType()),
+			Key:   key.AssignExpr(),
+			Value: value.AssignExpr(),
+			Error: value.ReturnsError(),
+		}, true
+	case *types.Pointer:
+		if !util.IsPtr(rhs.ExprType()) {
+			return nil, false
+		}
+		elem, ok := b.converterNode(lhsType.Elem(), bmodel.NewDeref(rhs), converter)
+		if !ok {
+			return nil, false
+		}
+		if elem.ReturnsError() {
+			return gmodel.PointerConvAssignment{
+				LHS:  lhsExpr,
+				RHS:  rhsExpr,
+				Typ:  b.imports.TypeName(lhsType.Elem()),
+				Elem: elem.AssignExpr(),
+			}, true
+		}
+		addr := bmodel.NewAddress(elem, lhsType.Elem(), b.imports.TypeName(lhsType.Elem()), b.pkgName(option.StdconvPkgPath))
+		return gmodel.GuardedField{
+			Conditions: []string{rhsExpr + " != nil"},
+			Content:    gmodel.SimpleField{LHS: lhsExpr, RHS: addr.AssignExpr()},
+		}, true
+	}
+	return nil, false
+}
+
+// createWithStructConverter creates an assignment using the given struct converter.
+// The converter takes the root of the source, and optionally all the additional arguments,
+// regardless of how deep lhs is nested.
+func (b *assignmentBuilder) createWithStructConverter(
+	lhs, rhs bmodel.Node,
+	converter *option.StructConverter,
+) (gmodel.Assignment, error) {
+	root := rhs
+	for ; root.Parent() != nil; root = root.Parent() {
+	}
 
 	lhsExpr := lhs.AssignExpr()
 	posStr := b.fset.Position(converter.Pos())
+	argTypes := converter.ArgTypes()
+	if len(argTypes) != 1 && len(argTypes) != 1+len(b.additionalArgs) {
+		return nil, b.logger.ErrorAt(posStr, logger.CodeInvalidFunction, "function %v must take the source and optionally all the additional arguments", converter.Converter())
+	}
+
+	args := append([]bmodel.Node{root}, b.additionalArgs[:len(argTypes)-1]...)
+	argExprs := make([]string, len(args))
+	for i, arg := range args {
+		switch {
+		case types.AssignableTo(arg.ExprType(), argTypes[i]):
+			argExprs[i] = arg.AssignExpr()
+		case util.IsPtr(arg.ExprType()) && types.AssignableTo(util.DerefPtr(arg.ExprType()), argTypes[i]):
+			argExprs[i] = "*" + arg.AssignExpr()
+		default:
+			return nil, b.logger.ErrorAt(posStr, logger.CodeInvalidFunction, "function %v cannot take %v as the argument #%d", converter.Converter(), b.imports.TypeName(arg.ExprType()), i+1)
+		}
+	}
+	rhsExpr := fmt.Sprintf("%v(%v)", converter.Converter(), strings.Join(argExprs, ", "))
 
-	if converterNode != nil {
-		rhsExpr := converterNode.AssignExpr()
-		logger.Printf("%v: assignment found: %v = %v, err", posStr, lhsExpr, rhsExpr)
-		return gmodel.SimpleField{LHS: lhsExpr, RHS: rhsExpr, Error: converter.RetError()}, nil
+	if converter.RetError() {
+		// A call that returns an error cannot be a part of another expression.
+		if types.AssignableTo(converter.RetType(), lhs.ExprType()) {
+			b.logger.Printf("%v: assignment found: %v = %v, err", posStr, lhsExpr, rhsExpr)
+			return gmodel.SimpleField{LHS: lhsExpr, RHS: rhsExpr, Error: true}, nil
+		}
+	} else if casted, ok := b.castNode(lhs.ExprType(), bmodel.NewScalarNode(nil, rhsExpr, converter.RetType())); ok {
+		rhsExpr = casted.AssignExpr()
+		b.logger.Printf("%v: assignment found: %v = %v", posStr, lhsExpr, rhsExpr)
+		return gmodel.SimpleField{LHS: lhsExpr, RHS: rhsExpr, Error: casted.ReturnsError()}, nil
 	}
 
-	logger.Warnf("%v: no assignment for %v [%v]", posStr, lhsExpr, b.imports.TypeName(lhs.ExprType()))
+	b.warnNoAssignment(posStr, lhs)
 	return gmodel.NoMatchField{LHS: lhsExpr}, nil
 }
 
@@ -270,30 +697,38 @@ func (b *assignmentBuilder) createWithConverter(lhs, rhs bmodel.Node, converter
 // the returns error flag.
 // If a match is not found, it returns a NoMatchField with the lhs expression.
 func (b *assignmentBuilder) createWithMapper(lhs, rhs bmodel.Node, mapper *option.NameMatcher) (gmodel.Assignment, error) {
-	mappedNode := func() bmodel.Node {
-		root := rhs
-		for ; root.Parent() != nil; root = root.Parent() {
-		}
-
-		rhsNode, ok := b.resolveExpr(mapper.Src(), root)
-		if !ok {
-			return nil
-		}
-
-		casted, _ := b.castNode(lhs.ExprType(), rhsNode)
-		return casted
-	}()
+	root := rhs
+	for ; root.Parent() != nil; root = root.Parent() {
+	}
+	rhsNode, resolved := b.resolveExpr(mapper.Src(), root)
 
 	lhsExpr := lhs.AssignExpr()
 	posStr := b.fset.Position(mapper.Pos())
 
-	if mappedNode != nil {
-		rhsExpr := mappedNode.AssignExpr()
-		logger.Printf("%v: assignment found: %v = %v", posStr, lhs, rhs)
-		return gmodel.SimpleField{LHS: lhsExpr, RHS: rhsExpr, Error: mappedNode.ReturnsError()}, nil
+	if resolved {
+		if mappedNode, ok := b.castNode(lhs.ExprType(), rhsNode); ok {
+			rhsExpr := mappedNode.AssignExpr()
+			b.logger.Printf("%v: assignment found: %v = %v", posStr, lhs, rhs)
+			a := gmodel.SimpleField{LHS: lhsExpr, RHS: rhsExpr, Error: mappedNode.ReturnsError()}
+			return b.guardNilHops(lhs, mappedNode, a), nil
+		}
+
+		if a := b.nullableAssignment(lhs, rhsNode); a != nil {
+			b.logger.Printf("%v: assignment found: %v = %v (nullable)", posStr, lhsExpr, rhsNode.AssignExpr())
+			return b.guardNilHops(lhs, rhsNode, a), nil
+		}
+
+		a, err := b.derefAssignment(lhs, rhsNode)
+		if err != nil {
+			return nil, err
+		}
+		if a != nil {
+			b.logger.Printf("%v: assignment found: %v = *%v", posStr, lhsExpr, rhsNode.AssignExpr())
+			return b.guardNilHops(lhs, rhsNode, a), nil
+		}
 	}
 
-	logger.Warnf("%v: no assignment for %v [%v]", posStr, lhsExpr, b.imports.TypeName(lhs.ExprType()))
+	b.warnNoAssignment(posStr, lhs)
 	return gmodel.NoMatchField{LHS: lhsExpr}, nil
 }
 
@@ -325,43 +760,365 @@ func (b *assignmentBuilder) createWithTemplatedMapper(
 
 	if mappedNode != nil {
 		rhsExpr := mappedNode.AssignExpr()
-		logger.Printf("%v: assignment found: %v = %s", posStr, lhs, rhsExpr)
-		return gmodel.SimpleField{LHS: lhsExpr, RHS: rhsExpr, Error: mappedNode.ReturnsError()}, nil
+		b.logger.Printf("%v: assignment found: %v = %s", posStr, lhs, rhsExpr)
+		a := gmodel.SimpleField{LHS: lhsExpr, RHS: rhsExpr, Error: mappedNode.ReturnsError()}
+		return b.guardNilHops(lhs, mappedNode, a), nil
 	}
 
-	logger.Warnf("%v: no assignment for %v [%v]", posStr, lhsExpr, b.imports.TypeName(lhs.ExprType()))
+	b.warnNoAssignment(posStr, lhs)
 	return gmodel.NoMatchField{LHS: lhsExpr}, nil
 }
 
+// createWithFlatten creates an assignment for lhs from the field of the nested struct in rhs
+// that the flatten rule specifies, e.g. "dst.AddressCity = src.Address.City".
+// Since the nested struct is walked implicitly, the pointers on the path to the field are
+// always guarded against nil as a nested struct copy does.
+// It returns nil if lhs is not a prefixed name of any field of the nested struct,
+// or the rule is invalid; resolveFlattenRules has reported it.
+func (b *assignmentBuilder) createWithFlatten(lhs bmodel.Node, rule *option.FlattenRule) (gmodel.Assignment, error) {
+	nested, ok := b.flattenNested[rule]
+	if !ok {
+		return nil, nil
+	}
+
+	var a gmodel.Assignment
+	var matched bool
+	var err error
+	bmodel.IterateStructFields(nested, func(field bmodel.Node) (done bool) {
+		if !b.isStructFieldAccessible(nested, field.ObjName()) ||
+			!b.opts.CompareFieldName(rule.FlatName(field.ObjName()), lhs.MatcherExpr()) {
+			return
+		}
+		matched = true
+		a, _, err = b.assignField(lhs, field)
+		if a != nil && err == nil {
+			exprs := bmodel.NullCheckExprs(field)
+			if 0 < len(exprs) {
+				conditions := make([]string, len(exprs))
+				for i, expr := range exprs {
+					conditions[i] = expr + " != nil"
+				}
+				a = gmodel.GuardedField{Conditions: conditions, Content: a}
+			}
+		}
+		return true
+	})
+	if matched && a == nil && err == nil {
+		b.warnNoAssignment(b.fset.Position(rule.Pos()), lhs)
+		return gmodel.NoMatchField{LHS: lhs.AssignExpr()}, nil
+	}
+	return a, err
+}
+
+// createWithUnflatten creates an assignment that builds the lhs nested struct from the prefixed
+// fields in rhs that the unflatten rule specifies, e.g. "dst.Address.City = src.AddressCity".
+// The nested struct is initialized first if it is a pointer.
+// resolveFlattenRules has checked that the rule refers a nested struct.
+func (b *assignmentBuilder) createWithUnflatten(lhs, rhs bmodel.Node, rule *option.FlattenRule) (gmodel.Assignment, error) {
+	nestStruct := gmodel.NestStruct{InitExpr: b.nestInitExpr(lhs)}
+	var err error
+	bmodel.IterateStructFields(lhs, func(lhsField bmodel.Node) (done bool) {
+		if !b.isStructFieldAccessible(lhs, lhsField.ObjName()) {
+			return
+		}
+		if b.opts.ShouldSkip(lhsField.MatcherExpr()) {
+			nestStruct.Contents = append(nestStruct.Contents, gmodel.SkipField{LHS: lhsField.AssignExpr()})
+			return
+		}
+
+		var a gmodel.Assignment
+		bmodel.IterateStructFields(rhs, func(rhsField bmodel.Node) (done bool) {
+			if !b.isStructFieldAccessible(rhs, rhsField.ObjName()) ||
+				!b.opts.CompareFieldName(rule.FlatName(lhsField.ObjName()), rhsField.ObjName()) {
+				return
+			}
+			a, _, err = b.assignField(lhsField, rhsField)
+			return true
+		})
+		if err != nil {
+			return true
+		}
+		if a == nil {
+			b.warnNoAssignment(b.fset.Position(rule.Pos()), lhsField)
+			a = gmodel.NoMatchField{LHS: lhsField.AssignExpr()}
+		}
+		nestStruct.Contents = append(nestStruct.Contents, a)
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+	return nestStruct, nil
+}
+
+// guardNilHops wraps the assignment with null checks for the pointer hops in the rhs node
+// if the nil-safe mode is on. Unless all the hops are non-nil, lhs keeps its zero value,
+// or takes the literal value of the first ":fallback" that matches lhs.
+func (b *assignmentBuilder) guardNilHops(lhs, rhs bmodel.Node, a gmodel.Assignment) gmodel.Assignment {
+	if !b.opts.NilSafe {
+		return a
+	}
+	exprs := bmodel.NullCheckExprs(rhs)
+	if len(exprs) == 0 {
+		return a
+	}
+
+	conditions := make([]string, len(exprs))
+	for i, expr := range exprs {
+		conditions[i] = expr + " != nil"
+	}
+	guarded := gmodel.GuardedField{Conditions: conditions, Content: a}
+	for _, fallback := range b.opts.Fallbacks {
+		if fallback.Dst().Match(lhs.MatcherExpr(), true) {
+			guarded.Fallback = gmodel.SimpleField{LHS: lhs.AssignExpr(), RHS: fallback.Literal()}
+			break
+		}
+	}
+	return guarded
+}
+
+// appendGuarded appends a to assignments. If a and the last one are guarded by the same conditions,
+// e.g. the fields that ":flatten" or ":nilsafe" reads through the same pointers, a joins the guard
+// of the last one. An assignment that has a fallback or returns an error keeps its own guard,
+// since the error has to be checked right after it.
+func appendGuarded(assignments []gmodel.Assignment, a gmodel.Assignment) []gmodel.Assignment {
+	guarded, ok := a.(gmodel.GuardedField)
+	if !ok || guarded.Fallback != nil || guarded.RetError() || len(assignments) == 0 {
+		return append(assignments, a)
+	}
+	last, ok := assignments[len(assignments)-1].(gmodel.GuardedField)
+	if !ok || last.Fallback != nil || last.RetError() || !slices.Equal(last.Conditions, guarded.Conditions) {
+		return append(assignments, a)
+	}
+
+	group, ok := last.Content.(gmodel.NestStruct)
+	if !ok || group.InitExpr != "" || group.NullCheckExpr != "" {
+		group = gmodel.NestStruct{Contents: []gmodel.Assignment{last.Content}}
+	}
+	group.Contents = append(group.Contents, guarded.Content)
+	last.Content = group
+	assignments[len(assignments)-1] = last
+	return assignments
+}
+
 // castNode tries to cast a given node to a target type.
+// A type converter specified by ":conv:type" takes precedence over the other rules,
+// and an enum converter specified by ":enum" and variant converters specified by ":variant" follow it.
 // It checks if the target type is assignable from the node type,
 // if not, it tries to convert to the target type, if possible.
+// A built-in converter of the groups enabled by ":builtin" is tried first.
 // If the Stringer option is enabled and the target type is string,
 // and the node type complies with the Stringer interface,
 // it wraps the node in a Stringer node.
+// If the Text option is enabled, it converts between string and a type that has a Parse<Type> function,
+// or implements encoding.TextMarshaler or encoding.TextUnmarshaler.
 // If the Typecast option is enabled and the node type is convertible to the target type,
 // it creates a typecast node and returns it along with true.
+// The typecast checks the range of the value if the CheckedTypecast option is enabled and it may overflow.
+// If the target type is a nullable wrapper such as sql.NullString, it wraps the node as a valid value.
 // Otherwise, it returns nil and false.
 func (b *assignmentBuilder) castNode(lhsType types.Type, rhs bmodel.Node) (c bmodel.Node, ok bool) {
+	if converter := b.opts.LookupTypeConverter(rhs.ExprType(), lhsType); converter != nil {
+		return bmodel.NewConverterNode(rhs, converter), true
+	}
+
+	if converter := b.opts.LookupEnumConverter(rhs.ExprType(), lhsType); converter != nil {
+		return bmodel.NewConverterNode(rhs, b.funcBuilder.enumMapper(converter)), true
+	}
+
+	if variants := b.opts.LookupVariants(rhs.ExprType(), lhsType); 0 < len(variants) {
+		if b.retError {
+			return bmodel.NewConverterNode(rhs, b.funcBuilder.variantSwitch(lhsType, rhs.ExprType(), variants)), true
+		}
+		b.warnErrorReturnRequired("converting %v by :variant requires the function to return an error", rhs.AssignExpr())
+	}
+
 	if types.AssignableTo(rhs.ExprType(), lhsType) {
 		return rhs, true
 	}
 
+	if converter := b.opts.LookupBuiltinConverter(rhs.ExprType(), lhsType); converter != nil {
+		if !converter.RetError() || b.retError {
+			return bmodel.NewConverterNode(rhs, b.builtinCall(rhs.ExprType(), lhsType, converter)), true
+		}
+		b.warnErrorReturnRequired("converting %v by :builtin %v requires the function to return an error", rhs.AssignExpr(), converter.Group())
+	}
+
 	if b.opts.Stringer && types.AssignableTo(util.StringType(), lhsType) && util.CompliesStringer(rhs.ExprType()) {
 		return b.castNode(lhsType, bmodel.NewStringer(rhs))
 	}
 
+	if b.opts.Text {
+		if call := b.textCall(rhs.ExprType(), lhsType); call != nil {
+			if !call.RetError() || b.retError {
+				return bmodel.NewConverterNode(rhs, call), true
+			}
+			b.warnErrorReturnRequired("converting %v by :text requires the function to return an error", rhs.AssignExpr())
+		}
+	}
+
 	if b.opts.Typecast && types.ConvertibleTo(rhs.ExprType(), lhsType) && util.IsBasicType(lhsType.Underlying()) {
-		c, ok = bmodel.NewTypecast(b.pkg.Types.Scope(), b.imports, lhsType, rhs)
+		if b.opts.CheckedTypecast && util.MayOverflow(rhs.ExprType(), lhsType) {
+			c, ok = bmodel.NewCheckedTypecast(b.pkg.Types.Scope(), b.imports, lhsType, rhs, b.pkgName(option.StdconvPkgPath))
+		} else {
+			c, ok = bmodel.NewTypecast(b.pkg.Types.Scope(), b.imports, lhsType, rhs)
+		}
 		if !ok {
-			logger.Warnf("%v: typecast for %v is not implemented(yet) for %v",
-				b.fset.Position(b.methodPos), b.imports.TypeName(lhsType), rhs.AssignExpr())
+			b.logger.WarnAt(b.fset.Position(b.methodPos), logger.CodeUnsupportedConversion, "typecast for %v is not implemented(yet) for %v", b.imports.TypeName(lhsType), rhs.AssignExpr())
 		}
 		return
 	}
+
+	if b.opts.PtrCast && util.IsPtr(lhsType) && !util.IsPtr(rhs.ExprType()) {
+		elem := util.DerefPtr(lhsType)
+		if c, ok = b.castNode(elem, rhs); ok && !c.ReturnsError() {
+			return bmodel.NewAddress(c, elem, b.imports.TypeName(elem), b.pkgName(option.StdconvPkgPath)), true
+		}
+	}
+
+	if field := util.NullableValueField(lhsType); field != nil &&
+		!util.IsPtr(rhs.ExprType()) && util.NullableValueField(rhs.ExprType()) == nil {
+		if c, ok = b.castNode(field.Type(), rhs); ok && !c.ReturnsError() {
+			return bmodel.NewNullable(c, lhsType, b.imports.TypeName(lhsType), field.Name()), true
+		}
+	}
 	return nil, false
 }
 
+// builtinCall creates a call of the built-in converter from src into dst.
+func (b *assignmentBuilder) builtinCall(src, dst types.Type, converter *option.BuiltinConverter) *bmodel.FuncCall {
+	return bmodel.NewFuncCall(b.pkgName(converter.PkgPath()), converter.Name(), src, dst, converter.RetError())
+}
+
+// textCall creates a call of the function that converts src into dst in text form, or returns nil.
+// A string is parsed by the Parse<Type> function in the package of dst if it exists,
+// or by the UnmarshalText method of dst.
+// A value is formatted into a string by its MarshalText method.
+func (b *assignmentBuilder) textCall(src, dst types.Type) *bmodel.FuncCall {
+	str := util.StringType()
+	switch {
+	case types.Identical(src, str):
+		if fn, retError := util.LookupParseFunc(dst); fn != nil && (fn.Exported() || fn.Pkg() == b.pkg.Types) {
+			return bmodel.NewFuncCall(b.pkgName(fn.Pkg().Path()), fn.Name(), src, dst, retError)
+		}
+		if util.CompliesTextUnmarshaler(dst) {
+			name := fmt.Sprintf("UnmarshalText[%v]", b.imports.TypeName(dst))
+			return bmodel.NewFuncCall(b.pkgName(option.StdconvPkgPath), name, src, dst, true)
+		}
+	case types.Identical(dst, str):
+		if util.CompliesTextMarshaler(src) {
+			return bmodel.NewFuncCall(b.pkgName(option.StdconvPkgPath), "MarshalText", src, dst, true)
+		}
+	}
+	return nil
+}
+
+// pkgName returns the name of the package to qualify its members with in the generated code.
+// It is the name in the setup file, or the default name if the setup file doesn't import the package.
+// It returns "" for the package of the setup file and for the universe scope, i.e. pkgPath is "".
+func (b *assignmentBuilder) pkgName(pkgPath string) string {
+	if pkgPath == "" || pkgPath == b.pkg.Types.Path() {
+		return ""
+	}
+	if name, ok := b.imports.LookupName(pkgPath); ok {
+		return name
+	}
+	return path.Base(pkgPath)
+}
+
+// derefAssignment creates an assignment that dereferences the rhs pointer to assign lhs.
+// The assignment is guarded by a nil check, and opts.NilPolicy decides what happens to lhs
+// if rhs is nil. The "zero" policy assigns the zero value only in the arg style, since
+// the destination already has it in the return style. A pointer destination follows
+// the "zero" policy instead of the "error" policy, since it can hold nil.
+// It returns nil if the ":ptrcast" is off or the dereferenced value cannot be cast to lhs.
+func (b *assignmentBuilder) derefAssignment(lhs, rhs bmodel.Node) (gmodel.Assignment, error) {
+	if !b.opts.PtrCast || !util.IsPtr(rhs.ExprType()) {
+		return nil, nil
+	}
+	c, ok := b.castNode(lhs.ExprType(), bmodel.NewDeref(rhs))
+	if !ok {
+		return nil, nil
+	}
+
+	lhsExpr := lhs.AssignExpr()
+	guarded := gmodel.GuardedField{
+		Conditions: []string{rhs.NullCheckExpr() + " != nil"},
+		Content:    gmodel.SimpleField{LHS: lhsExpr, RHS: c.AssignExpr(), Error: c.ReturnsError()},
+	}
+	policy := b.opts.NilPolicy
+	if policy == gmodel.NilPolicyError && util.IsPtr(lhs.ExprType()) {
+		// A pointer destination can hold nil as it is.
+		policy = gmodel.NilPolicyZero
+	}
+	switch policy {
+	case gmodel.NilPolicyZero:
+		if b.opts.Style == gmodel.DstVarArg {
+			zero := util.ZeroValueExpr(lhs.ExprType(), b.imports.TypeName(lhs.ExprType()))
+			guarded.Fallback = gmodel.SimpleField{LHS: lhsExpr, RHS: zero}
+		}
+	case gmodel.NilPolicyError:
+		if !b.retError {
+			return nil, b.logger.Report(logger.Diagnostic{
+				Pos:      b.fset.Position(b.methodPos),
+				Severity: logger.SeverityError,
+				Code:     logger.CodeErrorReturnRequired,
+				Message:  `":ptrcast error" requires the function to return an error`,
+				Fix:      logger.FixReturnError,
+			})
+		}
+		guarded.Fallback = gmodel.ErrorField{Message: rhs.AssignExpr() + " is nil"}
+	}
+	return guarded, nil
+}
+
+// nullableAssignment creates an assignment between a nullable wrapper, such as sql.NullString
+// or sql.Null[T], and a plain value or pointer.
+// Unwrapping rhs is guarded by its Valid field, and wrapping a pointer rhs is guarded by a nil check.
+// If the guard fails, lhs takes its zero value, i.e. nil for a pointer or an invalid wrapper.
+// It is assigned only in the arg style, since the destination already has it in the return style.
+// It returns nil if neither side is a nullable wrapper or the value cannot be cast to lhs.
+func (b *assignmentBuilder) nullableAssignment(lhs, rhs bmodel.Node) gmodel.Assignment {
+	lhsType := lhs.ExprType()
+	rhsType := rhs.ExprType()
+
+	var condition string
+	var c bmodel.Node
+	var ok bool
+	if field := util.NullableValueField(rhsType); field != nil && util.NullableValueField(lhsType) == nil {
+		value := bmodel.NewStructFieldNode(rhs, field)
+		if util.IsPtr(lhsType) {
+			elem := util.DerefPtr(lhsType)
+			if c, ok = b.castNode(elem, value); ok && !c.ReturnsError() {
+				c = bmodel.NewAddress(c, elem, b.imports.TypeName(elem), b.pkgName(option.StdconvPkgPath))
+			} else {
+				ok = false
+			}
+		} else {
+			c, ok = b.castNode(lhsType, value)
+		}
+		condition = rhs.AssignExpr() + ".Valid"
+	} else if util.NullableValueField(lhsType) != nil &&
+		util.IsPtr(rhsType) && util.NullableValueField(util.DerefPtr(rhsType)) == nil {
+		c, ok = b.castNode(lhsType, bmodel.NewDeref(rhs))
+		condition = rhs.NullCheckExpr() + " != nil"
+	}
+	if !ok {
+		return nil
+	}
+
+	lhsExpr := lhs.AssignExpr()
+	guarded := gmodel.GuardedField{
+		Conditions: []string{condition},
+		Content:    gmodel.SimpleField{LHS: lhsExpr, RHS: c.AssignExpr(), Error: c.ReturnsError()},
+	}
+	if b.opts.Style == gmodel.DstVarArg {
+		zero := util.ZeroValueExpr(lhsType, b.imports.TypeName(lhsType))
+		guarded.Fallback = gmodel.SimpleField{LHS: lhsExpr, RHS: zero}
+	}
+	return guarded
+}
+
 // isStructFieldAccessible returns true if the given struct field is accessible from the current package.
 func (b *assignmentBuilder) isStructFieldAccessible(structNode bmodel.Node, leafName string) bool {
 	structType := util.DerefPtr(structNode.ExprType())
@@ -535,7 +1292,10 @@ func (b *assignmentBuilder) sliceToSlice(lhs, rhs bmodel.Node) (a gmodel.Assignm
 		return
 	}
 
-	if types.AssignableTo(rhsElem, lhsElem) {
+	// The element conversions that castNode ranks ahead take precedence over a plain copy or a typecast.
+	overAssign, overTypecast := b.precedingConversions(lhsElem, rhsElem)
+
+	if !overAssign && types.AssignableTo(rhsElem, lhsElem) {
 		if util.IsBasicType(rhsElem) {
 			a = gmodel.SliceAssignment{
 				LHS: lhs.AssignExpr(),
@@ -552,7 +1312,9 @@ func (b *assignmentBuilder) sliceToSlice(lhs, rhs bmodel.Node) (a gmodel.Assignm
 		return
 	}
 
-	if b.opts.Typecast && types.ConvertibleTo(rhsElem, lhsElem) {
+	// A checked typecast needs the element conversion in the loop to handle its error.
+	checked := b.opts.CheckedTypecast && util.MayOverflow(rhsElem, lhsElem)
+	if !overTypecast && !checked && b.opts.Typecast && types.ConvertibleTo(rhsElem, lhsElem) {
 		a = gmodel.SliceTypecastAssignment{
 			LHS:  lhs.AssignExpr(),
 			RHS:  rhs.AssignExpr(),
@@ -561,5 +1323,216 @@ func (b *assignmentBuilder) sliceToSlice(lhs, rhs bmodel.Node) (a gmodel.Assignm
 		}
 		return
 	}
+
+	elem, ok, err := b.elemNode(lhsElem, rhsElem, "e", rhs.AssignExpr()+"[i]")
+	if !ok || err != nil {
+		return
+	}
+	a = gmodel.SliceConvAssignment{
+		LHS:   lhs.AssignExpr(),
+		RHS:   rhs.AssignExpr(),
+		Typ:   "[]" + b.imports.TypeName(lhsElem),
+		Elem:  elem.AssignExpr(),
+		Error: elem.ReturnsError(),
+	}
+	return
+}
+
+// precedingConversions reports whether castNode has a conversion from rhsType into lhsType that it ranks
+// ahead of an assignment, and one that it ranks ahead of a typecast, respectively.
+// The former are the ":conv:type", ":enum" and ":variant" converters, and the latter add
+// the ":builtin" converters, a Stringer and the ":text" conversions to them.
+func (b *assignmentBuilder) precedingConversions(lhsType, rhsType types.Type) (overAssign, overTypecast bool) {
+	overAssign = b.opts.LookupTypeConverter(rhsType, lhsType) != nil ||
+		b.opts.LookupEnumConverter(rhsType, lhsType) != nil ||
+		0 < len(b.opts.LookupVariants(rhsType, lhsType))
+	overTypecast = overAssign ||
+		b.opts.LookupBuiltinConverter(rhsType, lhsType) != nil ||
+		(b.opts.Stringer && types.AssignableTo(util.StringType(), lhsType) && util.CompliesStringer(rhsType)) ||
+		(b.opts.Text && b.textCall(rhsType, lhsType) != nil)
 	return
 }
+
+// arrayAssignment attempts to create an assignment between arrays, or between an array and a slice.
+// The elements are converted by the same rules as sliceToSlice applies.
+// Arrays must have the same length. An array takes the elements of a slice after checking
+// its length at runtime, which requires the function to return an error.
+// If neither is possible, this function returns nil.
+func (b *assignmentBuilder) arrayAssignment(lhs, rhs bmodel.Node) (a gmodel.Assignment, err error) {
+	lhsType := lhs.ExprType()
+	rhsType := rhs.ExprType()
+	lhsElem, lhsLen := util.ArrayElement(lhsType)
+	rhsElem, rhsLen := util.ArrayElement(rhsType)
+	methodPosStr := b.fset.Position(b.methodPos)
+
+	switch {
+	case lhsElem != nil && rhsElem != nil:
+		if lhsLen != rhsLen {
+			b.logger.WarnAt(methodPosStr, logger.CodeUnsupportedConversion, "cannot copy %v to %v since their lengths differ", b.imports.TypeName(rhsType), b.imports.TypeName(lhsType))
+			return
+		}
+	case rhsElem != nil:
+		if lhsElem = util.SliceElement(lhsType); lhsElem == nil {
+			return
+		}
+	case lhsElem != nil:
+		if rhsElem = util.SliceElement(rhsType); rhsElem == nil {
+			return
+		}
+		if !b.retError {
+			b.warnErrorReturnRequired("copying %v to %v requires the function to return an error for the length check", rhs.AssignExpr(), lhs.AssignExpr())
+			return
+		}
+	default:
+		return
+	}
+
+	elem, ok, err := b.elemNode(lhsElem, rhsElem, "e", rhs.AssignExpr()+"[i]")
+	if !ok || err != nil {
+		return
+	}
+
+	switch {
+	case util.IsArrayType(lhsType) && util.IsArrayType(rhsType):
+		a = gmodel.ArrayConvAssignment{
+			LHS:   lhs.AssignExpr(),
+			RHS:   rhs.AssignExpr(),
+			Elem:  elem.AssignExpr(),
+			Error: elem.ReturnsError(),
+		}
+	case util.IsArrayType(rhsType):
+		a = gmodel.ArrayToSliceAssignment{
+			LHS:   lhs.AssignExpr(),
+			RHS:   rhs.AssignExpr(),
+			Typ:   "[]" + b.imports.TypeName(lhsElem),
+			Elem:  elem.AssignExpr(),
+			Error: elem.ReturnsError(),
+		}
+	default:
+		a = gmodel.SliceToArrayAssignment{
+			LHS:   lhs.AssignExpr(),
+			RHS:   rhs.AssignExpr(),
+			Len:   lhsLen,
+			Elem:  elem.AssignExpr(),
+			Error: elem.ReturnsError(),
+		}
+	}
+	return
+}
+
+// mapToMap attempts to create a map-to-map assignment between the given
+// left-hand side and right-hand side nodes. The keys and the values are
+// converted by the same rules as castNode applies to a field, i.e. they are
+// either assignable, typecast-able or stringer-able. If either of them cannot
+// be converted, this function returns nil.
+func (b *assignmentBuilder) mapToMap(lhs, rhs bmodel.Node) (a gmodel.Assignment, err error) {
+	lhsKey, lhsElem := util.MapKeyElem(lhs.ExprType())
+	rhsKey, rhsElem := util.MapKeyElem(rhs.ExprType())
+	if lhsKey == nil || rhsKey == nil {
+		return
+	}
+
+	key, ok := b.castElemNode(lhsKey, bmodel.NewScalarNode(nil, "k", rhsKey), "key of "+rhs.AssignExpr())
+	if !ok {
+		return
+	}
+	value, ok, err := b.elemNode(lhsElem, rhsElem, "v", rhs.AssignExpr()+"[k]")
+	if !ok || err != nil {
+		return
+	}
+
+	a = gmodel.MapAssignment{
+		LHS:   lhs.AssignExpr(),
+		RHS:   rhs.AssignExpr(),
+		Typ:   b.imports.TypeName(lhs.ExprType()),
+		Key:   key.AssignExpr(),
+		Value: value.AssignExpr(),
+		Error: value.ReturnsError(),
+	}
+	return
+}
+
+// elemNode returns a node that converts an element of a slice or a map into lhsType.
+// name is the variable name that refers the element in the loop, and label describes the element
+// in the error of a checked typecast, such as "src.Sizes[i]".
+// Other than the rules of castNode, a struct element is converted by a copier function.
+func (b *assignmentBuilder) elemNode(lhsType, rhsType types.Type, name, label string) (bmodel.Node, bool, error) {
+	node := bmodel.NewScalarNode(nil, name, rhsType)
+	if c, ok := b.castElemNode(lhsType, node, label); ok {
+		return c, true, nil
+	}
+	return b.copierNode(lhsType, node)
+}
+
+// castElemNode is castNode for a loop variable. A checked typecast names the value as label
+// in its error, since the loop variable means nothing outside the generated code.
+func (b *assignmentBuilder) castElemNode(lhsType types.Type, rhs bmodel.Node, label string) (bmodel.Node, bool) {
+	c, ok := b.castNode(lhsType, rhs)
+	if checked, isChecked := c.(bmodel.CheckedTypecastEntry); isChecked {
+		c = checked.WithLabel(label)
+	}
+	return c, ok
+}
+
+// copierNode returns a node that converts the given node into lhsType by a copier function.
+// Both of the types must be structs or pointers of structs.
+// A pointer cannot be converted into a non-pointer since the pointer may be nil.
+// A copier that returns an error cannot be used unless the function returns an error, too.
+func (b *assignmentBuilder) copierNode(lhsType types.Type, rhs bmodel.Node) (bmodel.Node, bool, error) {
+	rhsType := rhs.ExprType()
+	if !util.IsStructType(util.DerefPtr(lhsType)) || !util.IsStructType(util.DerefPtr(rhsType)) {
+		return nil, false, nil
+	}
+	if util.IsPtr(rhsType) && !util.IsPtr(lhsType) {
+		return nil, false, nil
+	}
+
+	copier, err := b.lookupCopier(lhsType, rhsType)
+	if err != nil {
+		return nil, false, err
+	}
+	if copier.RetError && !b.retError {
+		b.warnErrorReturnRequired("copying %v to %v by %v requires the function to return an error",
+			b.imports.TypeName(rhsType), b.imports.TypeName(lhsType), copier.Name)
+		return nil, false, nil
+	}
+	if copier.RetError && !util.IsPtr(lhsType) {
+		b.logger.WarnAt(b.fset.Position(b.methodPos), logger.CodeErrorReturnRequired, "copier %v returns an error so that it cannot be dereferenced for %v", copier.Name, b.imports.TypeName(lhsType))
+		return nil, false, nil
+	}
+	return bmodel.NewCopierNode(rhs, copier, lhsType), true, nil
+}
+
+// lookupCopier returns a copier that converts rhsType into lhsType under the options of the method.
+// If there is no such copier in the file yet, it creates a new one along with its function.
+func (b *assignmentBuilder) lookupCopier(lhsType, rhsType types.Type) (*bmodel.Copier, error) {
+	opts := b.opts.CopierOptions()
+	for _, copier := range b.funcBuilder.copiers {
+		if !copier.IsRoot && copier.Opts.SameCopierOptions(opts) && copier.MarkHandle(lhsType, rhsType) {
+			return copier, nil
+		}
+	}
+	return b.funcBuilder.createCopier(util.DerefPtr(lhsType), util.DerefPtr(rhsType), opts, b.methodPos)
+}
+
+// warnNoAssignment reports that nothing is assigned to lhs, and suggests ":skip" to leave it unassigned.
+func (b *assignmentBuilder) warnNoAssignment(pos token.Position, lhs bmodel.Node) {
+	_ = b.logger.Report(logger.Diagnostic{
+		Pos:      pos,
+		Severity: logger.SeverityWarning,
+		Code:     logger.CodeNoAssignment,
+		Message:  fmt.Sprintf("no assignment for %v [%v]", lhs.AssignExpr(), b.imports.TypeName(lhs.ExprType())),
+		Fix:      fmt.Sprintf(`add ":skip %v" to the method to leave it unassigned`, lhs.MatcherExpr()),
+	})
+}
+
+// warnErrorReturnRequired reports that the conversion is skipped since the method doesn't return an error.
+func (b *assignmentBuilder) warnErrorReturnRequired(format string, a ...any) {
+	_ = b.logger.Report(logger.Diagnostic{
+		Pos:      b.fset.Position(b.methodPos),
+		Severity: logger.SeverityWarning,
+		Code:     logger.CodeErrorReturnRequired,
+		Message:  fmt.Sprintf(format, a...),
+		Fix:      logger.FixReturnError,
+	})
+}
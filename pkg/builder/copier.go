@@ -0,0 +1,146 @@
+package builder
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"strings"
+	"unicode"
+
+	bmodel "github.com/reedom/convergen/v8/pkg/builder/model"
+	gmodel "github.com/reedom/convergen/v8/pkg/generator/model"
+	"github.com/reedom/convergen/v8/pkg/logger"
+	"github.com/reedom/convergen/v8/pkg/option"
+)
+
+// createCopier creates a copier that converts rhsType into lhsType and builds its function.
+// Both of the types must be non-pointer struct types.
+// The copier is registered before its function body is built so that
+// a recursive type can refer the copier itself.
+// A copier function may return an error; it does if any of its assignments does.
+func (p *FunctionBuilder) createCopier(
+	lhsType, rhsType types.Type,
+	opts option.Options,
+	pos token.Pos,
+) (*bmodel.Copier, error) {
+	lhsPtr := types.NewPointer(lhsType)
+	rhsPtr := types.NewPointer(rhsType)
+	name := p.copierName(lhsType, rhsType)
+	copier := bmodel.NewCopier(name, lhsPtr, rhsPtr)
+	copier.Opts = opts
+	p.copiers = append(p.copiers, copier)
+
+	srcVar := gmodel.Var{
+		Name:     "src",
+		Type:     p.imports.TypeName(rhsType),
+		Pointer:  true,
+		External: p.imports.IsExternal(rhsType),
+	}
+	dstVar := gmodel.Var{
+		Name:     "dst",
+		Type:     p.imports.TypeName(lhsType),
+		Pointer:  true,
+		External: p.imports.IsExternal(lhsType),
+	}
+
+	builder := &assignmentBuilder{
+		file:        p.file,
+		fset:        p.fset,
+		pkg:         p.pkg,
+		imports:     p.imports,
+		logger:      p.logger,
+		methodPos:   pos,
+		opts:        opts,
+		lhsVar:      dstVar,
+		rhsVar:      srcVar,
+		funcName:    name,
+		funcBuilder: p,
+		retError:    true,
+	}
+	lhsRoot := bmodel.NewRootNode(dstVar.Name, lhsPtr)
+	rhsRoot := bmodel.NewRootNode(srcVar.Name, rhsPtr)
+	assignments, err := builder.structToStruct(lhsRoot, rhsRoot, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range assignments {
+		if a.RetError() {
+			copier.RetError = true
+			break
+		}
+	}
+	if copier.RetError && 1 < copier.HandleCount {
+		// The calls of the copier in its own body have been generated without error handling.
+		return nil, p.logger.ErrorAt(p.fset.Position(pos), logger.CodeUnsupportedConversion, "recursive conversion from %v to %v cannot return an error", srcVar.Type, dstVar.Type)
+	}
+
+	p.helpers = append(p.helpers, &gmodel.Function{
+		Comments:    []string{fmt.Sprintf("// %v copies %v into %v.", name, srcVar.Type, dstVar.Type)},
+		Name:        name,
+		Src:         srcVar,
+		Dst:         dstVar,
+		DstVarStyle: gmodel.DstVarReturn,
+		RetError:    copier.RetError,
+		NilCheck:    true,
+		Assignments: assignments,
+	})
+	return copier, nil
+}
+
+// copierName returns a function name for a copier that converts rhsType into lhsType,
+// e.g. "copyDomainPetToModelPet".
+func (p *FunctionBuilder) copierName(lhsType, rhsType types.Type) string {
+	return p.helperName("copy", lhsType, rhsType)
+}
+
+// helperName returns a function name for a helper that converts rhsType into lhsType,
+// which starts with the verb.
+// A numeric suffix is added if the name has already been taken by another helper.
+func (p *FunctionBuilder) helperName(verb string, lhsType, rhsType types.Type) string {
+	base := fmt.Sprintf("%v%vTo%v", verb, identFromType(p.imports.TypeName(rhsType)), identFromType(p.imports.TypeName(lhsType)))
+	name := base
+	for i := 2; p.hasHelperName(name); i++ {
+		name = fmt.Sprintf("%v%d", base, i)
+	}
+	return name
+}
+
+// hasHelperName reports whether the name has already been taken by a copier, an enum mapper or a variant switch.
+func (p *FunctionBuilder) hasHelperName(name string) bool {
+	for _, copier := range p.copiers {
+		if copier.Name == name {
+			return true
+		}
+	}
+	for _, mapper := range p.enumMappers {
+		if mapper.Name == name {
+			return true
+		}
+	}
+	for _, s := range p.variantSwitches {
+		if s.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// identFromType converts a type name into a part of an identifier in pascal case.
+// For example, it returns "DomainPet" for "domain.Pet".
+func identFromType(typeName string) string {
+	var sb strings.Builder
+	upper := true
+	for _, r := range typeName {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
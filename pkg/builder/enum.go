@@ -0,0 +1,66 @@
+package builder
+
+import (
+	"fmt"
+	"go/types"
+
+	bmodel "github.com/reedom/convergen/v8/pkg/builder/model"
+	gmodel "github.com/reedom/convergen/v8/pkg/generator/model"
+	"github.com/reedom/convergen/v8/pkg/option"
+)
+
+// enumMapper returns the mapper for the enum converter.
+// The mapper function is created on the first call for the converter, and it maps
+// every source constant to its pair by a switch statement.
+// A value that is not any of the constants results in the zero value of the destination type.
+func (p *FunctionBuilder) enumMapper(conv *option.EnumConverter) *bmodel.EnumMapper {
+	if mapper, ok := p.enumMappers[conv]; ok {
+		return mapper
+	}
+
+	srcType := conv.SrcType()
+	dstType := conv.DstType()
+	name := p.helperName("convert", dstType, srcType)
+	mapper := bmodel.NewEnumMapper(name, srcType, dstType)
+	if p.enumMappers == nil {
+		p.enumMappers = make(map[*option.EnumConverter]*bmodel.EnumMapper)
+	}
+	p.enumMappers[conv] = mapper
+
+	srcVar := gmodel.Var{
+		Name:     "src",
+		Type:     p.imports.TypeName(srcType),
+		External: p.imports.IsExternal(srcType),
+	}
+	dstVar := gmodel.Var{
+		Name:     "dst",
+		Type:     p.imports.TypeName(dstType),
+		External: p.imports.IsExternal(dstType),
+	}
+
+	pairs := conv.Pairs()
+	cases := make([]gmodel.SwitchCase, len(pairs))
+	for i, pair := range pairs {
+		cases[i] = gmodel.SwitchCase{Value: p.constName(pair.Src), RHS: p.constName(pair.Dst)}
+	}
+
+	p.helpers = append(p.helpers, &gmodel.Function{
+		Comments:    []string{fmt.Sprintf("// %v converts %v into %v.", name, srcVar.Type, dstVar.Type)},
+		Name:        name,
+		Src:         srcVar,
+		Dst:         dstVar,
+		DstVarStyle: gmodel.DstVarReturn,
+		Assignments: []gmodel.Assignment{
+			gmodel.SwitchField{LHS: dstVar.Name, Expr: srcVar.Name, Cases: cases},
+		},
+	})
+	return mapper
+}
+
+// constName returns the expression that refers the constant with its package name, e.g. "model.Active".
+func (p *FunctionBuilder) constName(c *types.Const) string {
+	if pkgName, ok := p.imports.LookupName(c.Pkg().Path()); ok {
+		return fmt.Sprintf("%v.%v", pkgName, c.Name())
+	}
+	return c.Name()
+}
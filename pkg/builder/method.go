@@ -1,6 +1,7 @@
 package builder
 
 import (
+	"errors"
 	"fmt"
 	"go/ast"
 	"go/token"
@@ -9,6 +10,7 @@ import (
 	bmodel "github.com/reedom/convergen/v8/pkg/builder/model"
 	gmodel "github.com/reedom/convergen/v8/pkg/generator/model"
 	"github.com/reedom/convergen/v8/pkg/logger"
+	"github.com/reedom/convergen/v8/pkg/option"
 	"github.com/reedom/convergen/v8/pkg/util"
 	"golang.org/x/tools/go/packages"
 )
@@ -20,6 +22,12 @@ type FunctionBuilder struct {
 	fset    *token.FileSet    // The fileset used to read the method.
 	pkg     *packages.Package // The package where the method belongs.
 	imports util.ImportNames  // The import names to be used.
+	logger  *logger.Logger    // The logger to report the errors and warnings to.
+
+	copiers         []*bmodel.Copier                             // The copiers shared by all the methods in the file.
+	enumMappers     map[*option.EnumConverter]*bmodel.EnumMapper // The enum mappers shared by all the methods in the file.
+	variantSwitches []*bmodel.VariantSwitch                      // The type switch helpers shared by all the methods in the file.
+	helpers         []*gmodel.Function                           // The helper functions that are not yet returned by CreateFunctions.
 }
 
 // NewFunctionBuilder is a constructor that returns a new instance of
@@ -29,25 +37,36 @@ func NewFunctionBuilder(
 	fset *token.FileSet,
 	pkg *packages.Package,
 	imports util.ImportNames,
+	logger *logger.Logger,
 ) *FunctionBuilder {
 	return &FunctionBuilder{
 		file:    file,
 		fset:    fset,
 		pkg:     pkg,
 		imports: imports,
+		logger:  logger,
 	}
 }
 
 // CreateFunctions is a method that creates functions based on a slice of
 // method entries.
+// The helper functions that the methods require are appended after them.
+// It builds all the methods even if some of them fail, and returns the errors joined.
 func (p *FunctionBuilder) CreateFunctions(methods []*bmodel.MethodEntry) ([]*gmodel.Function, error) {
-	functions := make([]*gmodel.Function, len(methods))
-	var err error
-	for i, method := range methods {
-		functions[i], err = p.CreateFunction(method)
+	functions := make([]*gmodel.Function, 0, len(methods))
+	var errs []error
+	for _, method := range methods {
+		fn, err := p.CreateFunction(method)
 		if err != nil {
-			return nil, err
+			errs = append(errs, err)
+			continue
 		}
+		functions = append(functions, fn)
+	}
+	functions = append(functions, p.helpers...)
+	p.helpers = nil
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
 	}
 	return functions, nil
 }
@@ -61,25 +80,25 @@ func (p *FunctionBuilder) CreateFunction(m *bmodel.MethodEntry) (*gmodel.Functio
 	additionalArgs := m.AdditionalArgVars()
 
 	if m.Opts.Reverse && 0 < len(additionalArgs) {
-		return nil, logger.Errorf("%v: reverse cannot be used with additional arguments", p.fset.Position(m.Method.Pos()))
+		return nil, p.logger.ErrorAt(p.fset.Position(m.Method.Pos()), logger.CodeInvalidMethod, "reverse cannot be used with additional arguments")
 	}
 
 	if util.IsInvalidType(src.Type()) {
-		return nil, logger.Errorf("%v: src type is not defined. make sure to be imported", p.fset.Position(src.Pos()))
+		return nil, p.logger.ErrorAt(p.fset.Position(src.Pos()), logger.CodeInvalidMethod, "src type is not defined. make sure to be imported")
 	}
 	if util.IsInvalidType(dst.Type()) {
-		return nil, logger.Errorf("%v: dst type is not defined. make sure to be imported", p.fset.Position(dst.Pos()))
+		return nil, p.logger.ErrorAt(p.fset.Position(dst.Pos()), logger.CodeInvalidMethod, "dst type is not defined. make sure to be imported")
 	}
 	for _, arg := range additionalArgs {
 		if util.IsInvalidType(arg.Type()) {
-			return nil, logger.Errorf("%v: arg type is not defined. make sure to be imported", p.fset.Position(arg.Pos()))
+			return nil, p.logger.ErrorAt(p.fset.Position(arg.Pos()), logger.CodeInvalidMethod, "arg type is not defined. make sure to be imported")
 		}
 	}
 	if !util.IsStructType(util.DerefPtr(src.Type())) {
-		return nil, logger.Errorf("%v: src type should be a struct but %v", p.fset.Position(dst.Pos()), src.Type().Underlying().String())
+		return nil, p.logger.ErrorAt(p.fset.Position(dst.Pos()), logger.CodeInvalidMethod, "src type should be a struct but %v", src.Type().Underlying().String())
 	}
 	if !util.IsStructType(util.DerefPtr(dst.Type())) {
-		return nil, logger.Errorf("%v: dst type should be a struct but %v", p.fset.Position(dst.Pos()), dst.Type().Underlying().String())
+		return nil, p.logger.ErrorAt(p.fset.Position(dst.Pos()), logger.CodeInvalidMethod, "dst type should be a struct but %v", dst.Type().Underlying().String())
 	}
 
 	srcDefName := "src"
@@ -96,7 +115,7 @@ func (p *FunctionBuilder) CreateFunction(m *bmodel.MethodEntry) (*gmodel.Functio
 	}
 	if m.Opts.Receiver != "" {
 		if srcVar.External {
-			return nil, logger.Errorf("%v: an external package type cannot be a receiver", p.fset.Position(m.Method.Pos()))
+			return nil, p.logger.ErrorAt(p.fset.Position(m.Method.Pos()), logger.CodeInvalidMethod, "an external package type cannot be a receiver")
 		}
 		srcVar.Name = m.Opts.Receiver
 	}
@@ -3,6 +3,7 @@ package model
 import (
 	"go/types"
 
+	"github.com/reedom/convergen/v8/pkg/option"
 	"github.com/reedom/convergen/v8/pkg/util"
 )
 
@@ -13,6 +14,8 @@ type Copier struct {
 	LHS         types.Type
 	RHS         types.Type
 	HandleCount int
+	RetError    bool           // true means the copier function returns an error as the second returning value.
+	Opts        option.Options // Opts are the options that the copier function is built with.
 }
 
 // NewCopier creates a new Copier.
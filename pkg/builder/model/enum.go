@@ -0,0 +1,42 @@
+package model
+
+import (
+	"go/types"
+)
+
+// EnumMapper contains a helper function information that maps the constants of a type to another.
+// It implements option.Converter so that a ConverterNode can call the function.
+type EnumMapper struct {
+	Name string // name becomes a mapper function's name.
+	Src  types.Type
+	Dst  types.Type
+}
+
+// NewEnumMapper creates a new EnumMapper.
+func NewEnumMapper(name string, src, dst types.Type) *EnumMapper {
+	return &EnumMapper{
+		Name: name,
+		Src:  src,
+		Dst:  dst,
+	}
+}
+
+// Converter returns the name of the mapper function.
+func (m *EnumMapper) Converter() string {
+	return m.Name
+}
+
+// ArgType returns the type of the mapper function's argument.
+func (m *EnumMapper) ArgType() types.Type {
+	return m.Src
+}
+
+// RetType returns the type of the mapper function's return value.
+func (m *EnumMapper) RetType() types.Type {
+	return m.Dst
+}
+
+// RetError always returns false since the mapper function never fails.
+func (m *EnumMapper) RetError() bool {
+	return false
+}
@@ -0,0 +1,50 @@
+package model
+
+import (
+	"go/types"
+)
+
+// FuncCall is a call of a function that converts a value of Src into Dst,
+// such as a built-in converter or a Parse<Type> function.
+// It implements option.Converter so that a ConverterNode can call the function.
+type FuncCall struct {
+	Name  string // Name is the qualified name of the function, or the type to convert into.
+	Src   types.Type
+	Dst   types.Type
+	Error bool // Error indicates whether the function returns an error as the second value.
+}
+
+// NewFuncCall creates a new FuncCall.
+// pkgName is the name of the package that the function belongs to in the generated code,
+// or "" if the function needs no qualifier.
+func NewFuncCall(pkgName, name string, src, dst types.Type, retError bool) *FuncCall {
+	if pkgName != "" {
+		name = pkgName + "." + name
+	}
+	return &FuncCall{
+		Name:  name,
+		Src:   src,
+		Dst:   dst,
+		Error: retError,
+	}
+}
+
+// Converter returns the qualified name of the function.
+func (c *FuncCall) Converter() string {
+	return c.Name
+}
+
+// ArgType returns the type of the value to convert.
+func (c *FuncCall) ArgType() types.Type {
+	return c.Src
+}
+
+// RetType returns the type of the destination.
+func (c *FuncCall) RetType() types.Type {
+	return c.Dst
+}
+
+// RetError returns true if the function returns an error.
+func (c *FuncCall) RetError() bool {
+	return c.Error
+}
@@ -175,11 +175,11 @@ func (n ScalarNode) NullCheckExpr() string {
 type ConverterNode struct {
 	arg Node
 	//pkgName   string
-	converter *option.FieldConverter
+	converter option.Converter
 }
 
 // NewConverterNode creates a new ConverterNode.
-func NewConverterNode(arg Node, converter *option.FieldConverter) Node {
+func NewConverterNode(arg Node, converter option.Converter) Node {
 	return ConverterNode{
 		arg:       arg,
 		converter: converter,
@@ -311,6 +311,57 @@ func (n TypecastEntry) ObjNullable() bool {
 	return n.inner.ObjNullable()
 }
 
+// CheckedTypecastEntry is a node that represents a typecast between numeric types
+// that returns an error if the destination type cannot represent the value.
+type CheckedTypecastEntry struct {
+	TypecastEntry
+	pkgName string
+	fn      string // fn is the stdconv function that casts the value.
+	label   string // label names the value in the error; the inner expression if empty.
+}
+
+// NewCheckedTypecast creates a new CheckedTypecastEntry.
+// pkgName is the name of the stdconv package in the generated code.
+// A cast from a floating-point type is checked by stdconv.CastFloat or stdconv.CastFloatToInt,
+// and the others by stdconv.CastInt.
+func NewCheckedTypecast(scope *types.Scope, imports util.ImportNames, t types.Type, inner Node, pkgName string) (Node, bool) {
+	typecast, ok := NewTypecast(scope, imports, t, inner)
+	if !ok {
+		return nil, false
+	}
+	fn := "CastInt"
+	if src, ok := inner.ExprType().Underlying().(*types.Basic); ok && src.Info()&types.IsFloat != 0 {
+		fn = "CastFloat"
+		if dst, ok := t.Underlying().(*types.Basic); ok && dst.Info()&types.IsInteger != 0 {
+			fn = "CastFloatToInt"
+		}
+	}
+	return CheckedTypecastEntry{TypecastEntry: typecast.(TypecastEntry), pkgName: pkgName, fn: fn}, true
+}
+
+// AssignExpr returns a value evaluate expression for assignment.
+// For example, it returns `stdconv.CastInt[int32](src.Count, "src.Count")`.
+func (n CheckedTypecastEntry) AssignExpr() string {
+	inner := n.inner.AssignExpr()
+	label := n.label
+	if label == "" {
+		label = inner
+	}
+	return fmt.Sprintf("%v.%v[%v](%v, %q)", n.pkgName, n.fn, n.expr, inner, label)
+}
+
+// WithLabel returns a copy of the node that names the value as label in its error.
+// For example, it is "src.Sizes[i]" for the loop variable of the elements of src.Sizes.
+func (n CheckedTypecastEntry) WithLabel(label string) CheckedTypecastEntry {
+	n.label = label
+	return n
+}
+
+// ReturnsError indicates whether the expression returns an error object as the second returning value.
+func (n CheckedTypecastEntry) ReturnsError() bool {
+	return true
+}
+
 // StringerEntry is a node that represents a Stringer interface.
 type StringerEntry struct {
 	inner Node
@@ -366,3 +417,264 @@ func (e StringerEntry) ReturnsError() bool {
 func (e StringerEntry) ObjNullable() bool {
 	return e.inner.ObjNullable()
 }
+
+// CopierNode is a node that represents a call of a copier function.
+// A copier function always takes a pointer of its source and returns a pointer of its destination.
+type CopierNode struct {
+	arg    Node
+	copier *Copier
+	typ    types.Type
+}
+
+// NewCopierNode creates a new CopierNode.
+// typ is the type that the expression should be evaluated to.
+func NewCopierNode(arg Node, copier *Copier, typ types.Type) Node {
+	return CopierNode{
+		arg:    arg,
+		copier: copier,
+		typ:    typ,
+	}
+}
+
+// Parent returns the container of the node or nil.
+func (n CopierNode) Parent() Node {
+	return n.arg.Parent()
+}
+
+// ObjName returns the ident of the leaf element.
+// For example, it returns "Status" in both of dst.User.Status or dst.User.Status().
+func (n CopierNode) ObjName() string {
+	return n.arg.ObjName()
+}
+
+// ObjNullable indicates whether the node itself is a pointer type so that it can be nil at runtime.
+func (n CopierNode) ObjNullable() bool {
+	return n.arg.ObjNullable()
+}
+
+// ExprType returns the evaluated result type of the node.
+// For example, it returns the type that "dst.User.Status()" returns.
+// An expression may be in converter form, such as "strconv.Itoa(dst.User.Status())".
+func (n CopierNode) ExprType() types.Type {
+	return n.typ
+}
+
+// ReturnsError indicates whether the expression returns an error object as the second returning value.
+func (n CopierNode) ReturnsError() bool {
+	return n.copier.RetError
+}
+
+// AssignExpr returns a value evaluate expression for assignment.
+// For example, it returns "dst.User.Name", "dst.User.Status()", "strconv.Itoa(dst.User.Score())", etc.
+func (n CopierNode) AssignExpr() string {
+	refStr := ""
+	if !util.IsPtr(n.arg.ExprType()) {
+		refStr = "&"
+	}
+	derefStr := ""
+	if !util.IsPtr(n.typ) {
+		derefStr = "*"
+	}
+	return fmt.Sprintf("%v%v(%v%v)", derefStr, n.copier.Name, refStr, n.arg.AssignExpr())
+}
+
+// MatcherExpr returns a value evaluate expression for assignment but omits the root variable name.
+// For example, it returns "User.Status()" in "dst.User.Status()".
+func (n CopierNode) MatcherExpr() string {
+	return n.arg.MatcherExpr()
+}
+
+// NullCheckExpr returns a value evaluate expression for null check conditional.
+// For example, it returns "dst.Node.Child".
+func (n CopierNode) NullCheckExpr() string {
+	return n.AssignExpr()
+}
+
+// DerefEntry is a node that represents a dereference of a pointer.
+// The caller is responsible for the nil check of the inner node.
+type DerefEntry struct {
+	inner Node
+}
+
+// NewDeref creates a new DerefEntry.
+func NewDeref(inner Node) Node {
+	return DerefEntry{inner: inner}
+}
+
+// ObjName returns the ident of the leaf element.
+// For example, it returns "Status" in both of dst.User.Status or dst.User.Status().
+func (n DerefEntry) ObjName() string {
+	return n.inner.ObjName()
+}
+
+// Parent returns the container of the node or nil.
+func (n DerefEntry) Parent() Node {
+	return n.inner.Parent()
+}
+
+// ExprType returns the evaluated result type of the node.
+// For example, it returns the type that "dst.User.Status()" returns.
+// An expression may be in converter form, such as "strconv.Itoa(dst.User.Status())".
+func (n DerefEntry) ExprType() types.Type {
+	return util.DerefPtr(n.inner.ExprType())
+}
+
+// AssignExpr returns a value evaluate expression for assignment.
+// For example, it returns "dst.User.Name", "dst.User.Status()", "strconv.Itoa(dst.User.Score())", etc.
+func (n DerefEntry) AssignExpr() string {
+	return "*" + n.inner.AssignExpr()
+}
+
+// MatcherExpr returns a value evaluate expression for assignment but omits the root variable name.
+// For example, it returns "User.Status()" in "dst.User.Status()".
+func (n DerefEntry) MatcherExpr() string {
+	return n.inner.MatcherExpr()
+}
+
+// NullCheckExpr returns a value evaluate expression for null check conditional.
+// For example, it returns "dst.Node.Child".
+func (n DerefEntry) NullCheckExpr() string {
+	return n.inner.NullCheckExpr()
+}
+
+// ReturnsError indicates whether the expression returns an error object as the second returning value.
+func (n DerefEntry) ReturnsError() bool {
+	return false
+}
+
+// ObjNullable indicates whether the node itself is a pointer type so that it can be nil at runtime.
+func (n DerefEntry) ObjNullable() bool {
+	return false
+}
+
+// AddressEntry is a node that represents a pointer to a copy of the inner value, by stdconv.Ptr.
+// It never refers the inner value directly, so that the destination does not share it with the source.
+type AddressEntry struct {
+	inner    Node
+	typ      types.Type
+	elemExpr string // The type argument of stdconv.Ptr, or empty if it is inferred from inner.
+	pkgName  string // The package name of stdconv.
+}
+
+// NewAddress creates a new AddressEntry that evaluates to *elemType.
+// The inner value must be assignable to elemType.
+// elemExpr is the type expression of elemType, e.g. "string", "model.User", and
+// pkgName is the package name of stdconv in the generated code.
+func NewAddress(inner Node, elemType types.Type, elemExpr, pkgName string) Node {
+	if types.Identical(inner.ExprType(), elemType) {
+		elemExpr = ""
+	}
+	return AddressEntry{inner: inner, typ: types.NewPointer(elemType), elemExpr: elemExpr, pkgName: pkgName}
+}
+
+// ObjName returns the ident of the leaf element.
+// For example, it returns "Status" in both of dst.User.Status or dst.User.Status().
+func (n AddressEntry) ObjName() string {
+	return n.inner.ObjName()
+}
+
+// Parent returns the container of the node or nil.
+func (n AddressEntry) Parent() Node {
+	return n.inner.Parent()
+}
+
+// ExprType returns the evaluated result type of the node.
+// For example, it returns the type that "dst.User.Status()" returns.
+// An expression may be in converter form, such as "strconv.Itoa(dst.User.Status())".
+func (n AddressEntry) ExprType() types.Type {
+	return n.typ
+}
+
+// AssignExpr returns a value evaluate expression for assignment.
+// For example, it returns "dst.User.Name", "dst.User.Status()", "strconv.Itoa(dst.User.Score())", etc.
+func (n AddressEntry) AssignExpr() string {
+	if n.elemExpr == "" {
+		return fmt.Sprintf("%v.Ptr(%v)", n.pkgName, n.inner.AssignExpr())
+	}
+	return fmt.Sprintf("%v.Ptr[%v](%v)", n.pkgName, n.elemExpr, n.inner.AssignExpr())
+}
+
+// MatcherExpr returns a value evaluate expression for assignment but omits the root variable name.
+// For example, it returns "User.Status()" in "dst.User.Status()".
+func (n AddressEntry) MatcherExpr() string {
+	return n.inner.MatcherExpr()
+}
+
+// NullCheckExpr returns a value evaluate expression for null check conditional.
+// For example, it returns "dst.Node.Child".
+func (n AddressEntry) NullCheckExpr() string {
+	return n.inner.NullCheckExpr()
+}
+
+// ReturnsError indicates whether the expression returns an error object as the second returning value.
+func (n AddressEntry) ReturnsError() bool {
+	return n.inner.ReturnsError()
+}
+
+// ObjNullable indicates whether the node itself is a pointer type so that it can be nil at runtime.
+func (n AddressEntry) ObjNullable() bool {
+	return false
+}
+
+// NullableEntry is a node that represents a nullable wrapper, such as sql.NullString,
+// that holds the inner value as a valid one.
+type NullableEntry struct {
+	inner     Node
+	typ       types.Type
+	typeExpr  string
+	valueName string
+}
+
+// NewNullable creates a new NullableEntry that evaluates to the wrapper type typ.
+// The inner value must be assignable to the value field of typ.
+// typeExpr is the type expression of typ, e.g. "sql.NullString".
+// valueName is the name of the value field of typ, e.g. "String".
+func NewNullable(inner Node, typ types.Type, typeExpr, valueName string) Node {
+	return NullableEntry{inner: inner, typ: typ, typeExpr: typeExpr, valueName: valueName}
+}
+
+// ObjName returns the ident of the leaf element.
+// For example, it returns "Status" in both of dst.User.Status or dst.User.Status().
+func (n NullableEntry) ObjName() string {
+	return n.inner.ObjName()
+}
+
+// Parent returns the container of the node or nil.
+func (n NullableEntry) Parent() Node {
+	return n.inner.Parent()
+}
+
+// ExprType returns the evaluated result type of the node.
+// For example, it returns the type that "dst.User.Status()" returns.
+// An expression may be in converter form, such as "strconv.Itoa(dst.User.Status())".
+func (n NullableEntry) ExprType() types.Type {
+	return n.typ
+}
+
+// AssignExpr returns a value evaluate expression for assignment.
+// For example, it returns "dst.User.Name", "dst.User.Status()", "strconv.Itoa(dst.User.Score())", etc.
+func (n NullableEntry) AssignExpr() string {
+	return fmt.Sprintf("%v{%v: %v, Valid: true}", n.typeExpr, n.valueName, n.inner.AssignExpr())
+}
+
+// MatcherExpr returns a value evaluate expression for assignment but omits the root variable name.
+// For example, it returns "User.Status()" in "dst.User.Status()".
+func (n NullableEntry) MatcherExpr() string {
+	return n.inner.MatcherExpr()
+}
+
+// NullCheckExpr returns a value evaluate expression for null check conditional.
+// For example, it returns "dst.Node.Child".
+func (n NullableEntry) NullCheckExpr() string {
+	return n.inner.NullCheckExpr()
+}
+
+// ReturnsError indicates whether the expression returns an error object as the second returning value.
+func (n NullableEntry) ReturnsError() bool {
+	return n.inner.ReturnsError()
+}
+
+// ObjNullable indicates whether the node itself is a pointer type so that it can be nil at runtime.
+func (n NullableEntry) ObjNullable() bool {
+	return false
+}
@@ -93,6 +93,34 @@ func TestTypecastEntry(t *testing.T) {
 	assert.False(t, ok)
 }
 
+func TestCheckedTypecastEntry(t *testing.T) {
+	innerNode := model.NewScalarNode(nil, "src.Count", types.Typ[types.Int64])
+	castType := types.Typ[types.Int32]
+
+	node, ok := model.NewCheckedTypecast(nil, nil, castType, innerNode, "stdconv")
+	assert.True(t, ok)
+	assert.Equal(t, "src.Count", node.ObjName())
+	assert.Equal(t, castType, node.ExprType())
+	assert.Equal(t, `stdconv.CastInt[int32](src.Count, "src.Count")`, node.AssignExpr())
+	assert.True(t, node.ReturnsError())
+
+	elemNode, ok := model.NewCheckedTypecast(nil, nil, castType, model.NewScalarNode(nil, "e", types.Typ[types.Int64]), "stdconv")
+	assert.True(t, ok)
+	labeled := elemNode.(model.CheckedTypecastEntry).WithLabel("src.Counts[i]")
+	assert.Equal(t, `stdconv.CastInt[int32](e, "src.Counts[i]")`, labeled.AssignExpr())
+
+	ratio := model.NewScalarNode(nil, "src.Ratio", types.Typ[types.Float64])
+	node, ok = model.NewCheckedTypecast(nil, nil, types.Typ[types.Float32], ratio, "stdconv")
+	assert.True(t, ok)
+	assert.Equal(t, `stdconv.CastFloat[float32](src.Ratio, "src.Ratio")`, node.AssignExpr())
+	node, ok = model.NewCheckedTypecast(nil, nil, types.Typ[types.Int16], ratio, "stdconv")
+	assert.True(t, ok)
+	assert.Equal(t, `stdconv.CastFloatToInt[int16](src.Ratio, "src.Ratio")`, node.AssignExpr())
+
+	_, ok = model.NewCheckedTypecast(nil, nil, nil, innerNode, "stdconv")
+	assert.False(t, ok)
+}
+
 func TestStringerEntry(t *testing.T) {
 	inner := model.NewScalarNode(nil, "Name", types.Universe.Lookup("int").Type())
 	entry := model.NewStringer(inner)
@@ -106,3 +134,50 @@ func TestStringerEntry(t *testing.T) {
 	assert.False(t, entry.ReturnsError())
 	assert.False(t, entry.ObjNullable())
 }
+
+func TestDerefEntry(t *testing.T) {
+	innerType := types.NewPointer(types.Typ[types.String])
+	innerNode := model.NewScalarNode(nil, "name", innerType)
+	node := model.NewDeref(innerNode)
+
+	assert.Equal(t, "name", node.ObjName())
+	assert.Nil(t, node.Parent())
+	assert.False(t, node.ObjNullable())
+	assert.Equal(t, types.Typ[types.String], node.ExprType())
+	assert.Equal(t, "*name", node.AssignExpr())
+	assert.Equal(t, innerNode.MatcherExpr(), node.MatcherExpr())
+	assert.Equal(t, "name", node.NullCheckExpr())
+	assert.False(t, node.ReturnsError())
+}
+
+func TestAddressEntry(t *testing.T) {
+	innerNode := model.NewScalarNode(nil, "name", types.Typ[types.String])
+	node := model.NewAddress(innerNode, types.Typ[types.String], "string", "stdconv")
+
+	assert.Equal(t, "name", node.ObjName())
+	assert.Nil(t, node.Parent())
+	assert.False(t, node.ObjNullable())
+	assert.True(t, types.Identical(types.NewPointer(types.Typ[types.String]), node.ExprType()))
+	assert.Equal(t, "stdconv.Ptr(name)", node.AssignExpr())
+	// The type argument is explicit if the inner value is only assignable to the element.
+	anyType := types.Universe.Lookup("any").Type()
+	assert.Equal(t, "stdconv.Ptr[any](name)", model.NewAddress(innerNode, anyType, "any", "stdconv").AssignExpr())
+	assert.Equal(t, innerNode.MatcherExpr(), node.MatcherExpr())
+	assert.Equal(t, "name", node.NullCheckExpr())
+	assert.False(t, node.ReturnsError())
+}
+
+func TestNullableEntry(t *testing.T) {
+	nullString := types.NewNamed(types.NewTypeName(token.NoPos, nil, "NullString", nil), types.NewStruct(nil, nil), nil)
+	innerNode := model.NewScalarNode(nil, "name", types.Typ[types.String])
+	node := model.NewNullable(innerNode, nullString, "sql.NullString", "String")
+
+	assert.Equal(t, "name", node.ObjName())
+	assert.Nil(t, node.Parent())
+	assert.False(t, node.ObjNullable())
+	assert.True(t, types.Identical(nullString, node.ExprType()))
+	assert.Equal(t, "sql.NullString{String: name, Valid: true}", node.AssignExpr())
+	assert.Equal(t, innerNode.MatcherExpr(), node.MatcherExpr())
+	assert.Equal(t, "name", node.NullCheckExpr())
+	assert.False(t, node.ReturnsError())
+}
@@ -73,6 +73,12 @@ func (n StructFieldNode) NullCheckExpr() string {
 	return fmt.Sprintf("%v.%v", n.parent.AssignExpr(), n.field.Name())
 }
 
+// TagValue returns the name part of the struct tag associated with key.
+// For example, it returns "user_id" for `json:"user_id,omitempty"` with key "json".
+func (n StructFieldNode) TagValue(key string) (string, bool) {
+	return util.LookupTagValue(n.parent.ExprType(), n.field, key)
+}
+
 // StructMethodNode represents a struct method.
 type StructMethodNode struct {
 	// container refers to the container struct type entry.
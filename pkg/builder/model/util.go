@@ -33,6 +33,7 @@ func IterateStructMethods(structNode Node, cb func(Node) (done bool)) {
 // IsRecursive checks if the given type is the same as any of the ancestor nodes in the
 // tree rooted at the given node.
 // If true, it means there is a recursive reference in the tree.
+// Pointers are dereferenced on both sides, so that "*Node" in "Node" is recursive.
 func IsRecursive(node Node, typ types.Type) bool {
 	childType := util.DerefPtr(typ)
 	if !util.IsNamedType(childType) {
@@ -41,9 +42,24 @@ func IsRecursive(node Node, typ types.Type) bool {
 
 	childStr := childType.String()
 	for p := node; p != nil; p = p.Parent() {
-		if p.ExprType().String() == childStr {
+		if util.DerefPtr(p.ExprType()).String() == childStr {
 			return true
 		}
 	}
 	return false
 }
+
+// NullCheckExprs returns null check expressions for every pointer hop on the path
+// from the root to the given node, ordered from the root side.
+// Neither the root nor the node itself is included; the root is the caller's argument
+// and the node itself is the value to be assigned.
+// For example, it returns ["src.Owner", "src.Owner.Address"] for "src.Owner.Address.City".
+func NullCheckExprs(node Node) []string {
+	var exprs []string
+	for p := node.Parent(); p != nil && p.Parent() != nil; p = p.Parent() {
+		if p.ObjNullable() {
+			exprs = append([]string{p.NullCheckExpr()}, exprs...)
+		}
+	}
+	return exprs
+}
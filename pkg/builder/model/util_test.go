@@ -0,0 +1,48 @@
+package model_test
+
+import (
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/reedom/convergen/v8/pkg/builder/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNullCheckExprs(t *testing.T) {
+	city := types.NewField(token.NoPos, nil, "City", types.Typ[types.String], false)
+	address := types.NewStruct([]*types.Var{city}, nil)
+	addressField := types.NewField(token.NoPos, nil, "Address", types.NewPointer(address), false)
+	owner := types.NewStruct([]*types.Var{addressField}, nil)
+	ownerField := types.NewField(token.NoPos, nil, "Owner", types.NewPointer(owner), false)
+	nameField := types.NewField(token.NoPos, nil, "Name", owner, false)
+	root := model.NewRootNode("src", types.NewPointer(types.NewStruct([]*types.Var{ownerField, nameField}, nil)))
+
+	ownerNode := model.NewStructFieldNode(root, ownerField)
+	addressNode := model.NewStructFieldNode(ownerNode, addressField)
+	cityNode := model.NewStructFieldNode(addressNode, city)
+	assert.Equal(t, []string{"src.Owner", "src.Owner.Address"}, model.NullCheckExprs(cityNode))
+	assert.Equal(t, []string{"src.Owner"}, model.NullCheckExprs(addressNode))
+	assert.Empty(t, model.NullCheckExprs(ownerNode))
+
+	nameNode := model.NewStructFieldNode(root, nameField)
+	assert.Empty(t, model.NullCheckExprs(model.NewStructFieldNode(nameNode, addressField)))
+}
+
+func TestIsRecursive(t *testing.T) {
+	obj := types.NewTypeName(token.NoPos, nil, "Node", nil)
+	node := types.NewNamed(obj, nil, nil)
+	next := types.NewField(token.NoPos, nil, "Next", types.NewPointer(node), false)
+	name := types.NewField(token.NoPos, nil, "Name", types.Typ[types.String], false)
+	node.SetUnderlying(types.NewStruct([]*types.Var{name, next}, nil))
+
+	other := types.NewNamed(types.NewTypeName(token.NoPos, nil, "Other", nil), types.NewStruct(nil, nil), nil)
+
+	root := model.NewRootNode("src", types.NewPointer(node))
+	assert.True(t, model.IsRecursive(root, next.Type()))
+	assert.False(t, model.IsRecursive(root, other))
+	assert.False(t, model.IsRecursive(root, name.Type()))
+
+	nextNode := model.NewStructFieldNode(root, next)
+	assert.True(t, model.IsRecursive(nextNode, node))
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"go/types"
+
+	"github.com/reedom/convergen/v8/pkg/option"
+)
+
+// VariantSwitch contains a helper function information that converts an interface value
+// by a type switch over its concrete types.
+// It implements option.Converter so that a ConverterNode can call the function.
+type VariantSwitch struct {
+	Name     string // name becomes a helper function's name.
+	Src      types.Type
+	Dst      types.Type
+	Variants []*option.VariantConverter
+}
+
+// NewVariantSwitch creates a new VariantSwitch.
+func NewVariantSwitch(name string, src, dst types.Type, variants []*option.VariantConverter) *VariantSwitch {
+	return &VariantSwitch{
+		Name:     name,
+		Src:      src,
+		Dst:      dst,
+		Variants: variants,
+	}
+}
+
+// Match returns true if the helper function converts src into dst by the same variants.
+func (s *VariantSwitch) Match(src, dst types.Type, variants []*option.VariantConverter) bool {
+	if !types.Identical(s.Src, src) || !types.Identical(s.Dst, dst) || len(s.Variants) != len(variants) {
+		return false
+	}
+	for i := range variants {
+		if s.Variants[i] != variants[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Converter returns the name of the helper function.
+func (s *VariantSwitch) Converter() string {
+	return s.Name
+}
+
+// ArgType returns the type of the helper function's argument.
+func (s *VariantSwitch) ArgType() types.Type {
+	return s.Src
+}
+
+// RetType returns the type of the helper function's return value.
+func (s *VariantSwitch) RetType() types.Type {
+	return s.Dst
+}
+
+// RetError always returns true since the value may hold an unknown concrete type.
+func (s *VariantSwitch) RetError() bool {
+	return true
+}
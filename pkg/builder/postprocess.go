@@ -31,28 +31,28 @@ func (p *FunctionBuilder) buildManipulator(
 	ret.RetError = m.RetError
 
 	if ret.Pkg != "" && !m.Func.Exported() {
-		return nil, logger.Errorf("%v: manipulator function %v is not exported", p.fset.Position(m.Pos), ret.FuncName())
+		return nil, p.logger.ErrorAt(p.fset.Position(m.Pos), logger.CodeInvalidFunction, "manipulator function %v is not exported", ret.FuncName())
 	}
 
 	if m.RetError && !retError {
-		return nil, logger.Errorf("%v: cannot use manipulator function %v due to mismatch of returning error", p.fset.Position(m.Pos), ret.FuncName())
+		return nil, p.logger.ErrorAt(p.fset.Position(m.Pos), logger.CodeInvalidFunction, "cannot use manipulator function %v due to mismatch of returning error", ret.FuncName())
 	}
 
 	if !types.AssignableTo(util.DerefPtr(m.DstSide), util.DerefPtr(dst.Type())) {
-		return nil, logger.Errorf("%v: manipulator function %v 1st arg type mismatch", p.fset.Position(m.Pos), ret.FuncName())
+		return nil, p.logger.ErrorAt(p.fset.Position(m.Pos), logger.CodeInvalidFunction, "manipulator function %v 1st arg type mismatch", ret.FuncName())
 	}
 
 	if !types.AssignableTo(util.DerefPtr(m.SrcSide), util.DerefPtr(src.Type())) {
-		return nil, logger.Errorf("%v: manipulator function %v 2nd arg type mismatch", p.fset.Position(m.Pos), ret.FuncName())
+		return nil, p.logger.ErrorAt(p.fset.Position(m.Pos), logger.CodeInvalidFunction, "manipulator function %v 2nd arg type mismatch", ret.FuncName())
 	}
 
 	if 0 < len(m.AdditionalArgs) {
 		if len(m.AdditionalArgs) != len(additionalArgs) {
-			return nil, logger.Errorf("%v: manipulator function %v additional args count mismatch", p.fset.Position(m.Pos), ret.FuncName())
+			return nil, p.logger.ErrorAt(p.fset.Position(m.Pos), logger.CodeInvalidFunction, "manipulator function %v additional args count mismatch", ret.FuncName())
 		}
 		for i, arg := range m.AdditionalArgs {
 			if !types.AssignableTo(arg, additionalArgs[i].Type()) {
-				return nil, logger.Errorf("%v: manipulator function %v %s arg type mismatch", p.fset.Position(m.Pos), ret.FuncName(), ordinalNumber(i+3))
+				return nil, p.logger.ErrorAt(p.fset.Position(m.Pos), logger.CodeInvalidFunction, "manipulator function %v %s arg type mismatch", ret.FuncName(), ordinalNumber(i+3))
 			}
 		}
 		ret.HasAdditionalArgs = true
@@ -0,0 +1,65 @@
+package builder
+
+import (
+	"fmt"
+	"go/types"
+
+	bmodel "github.com/reedom/convergen/v8/pkg/builder/model"
+	gmodel "github.com/reedom/convergen/v8/pkg/generator/model"
+	"github.com/reedom/convergen/v8/pkg/option"
+	"github.com/reedom/convergen/v8/pkg/util"
+)
+
+// variantSwitch returns the helper that converts an interface value of srcType into dstType
+// by the variant converters.
+// The helper function is created on the first call for the types and the variants, and it calls
+// the converter for the concrete type of the value by a type switch.
+func (p *FunctionBuilder) variantSwitch(dstType, srcType types.Type, variants []*option.VariantConverter) *bmodel.VariantSwitch {
+	for _, s := range p.variantSwitches {
+		if s.Match(srcType, dstType, variants) {
+			return s
+		}
+	}
+
+	name := p.helperName("convert", dstType, srcType)
+	s := bmodel.NewVariantSwitch(name, srcType, dstType, variants)
+	p.variantSwitches = append(p.variantSwitches, s)
+
+	srcVar := gmodel.Var{
+		Name:     "src",
+		Type:     p.imports.TypeName(srcType),
+		External: p.imports.IsExternal(srcType),
+	}
+	dstVar := gmodel.Var{
+		Name:     "dst",
+		Type:     p.imports.TypeName(dstType),
+		External: p.imports.IsExternal(dstType),
+	}
+
+	cases := make([]gmodel.TypeSwitchCase, len(variants))
+	for i, variant := range variants {
+		cases[i] = gmodel.TypeSwitchCase{
+			Type:  p.imports.TypeName(variant.ArgType()),
+			RHS:   variant.RHSExpr("v"),
+			Error: variant.RetError(),
+			Ptr:   util.IsPtr(variant.ArgType()),
+		}
+		// A nil pointer that the converter returns would make a non-nil interface.
+		if types.IsInterface(dstType) && util.IsTypedNilable(variant.RetType()) {
+			cases[i].RetType = p.imports.TypeName(variant.RetType())
+		}
+	}
+
+	p.helpers = append(p.helpers, &gmodel.Function{
+		Comments:    []string{fmt.Sprintf("// %v converts %v into %v by its concrete type.", name, srcVar.Type, dstVar.Type)},
+		Name:        name,
+		Src:         srcVar,
+		Dst:         dstVar,
+		DstVarStyle: gmodel.DstVarReturn,
+		RetError:    true,
+		Assignments: []gmodel.Assignment{
+			gmodel.TypeSwitchField{LHS: dstVar.Name, Expr: srcVar.Name, DstType: dstVar.Type, Cases: cases},
+		},
+	})
+	return s
+}
@@ -1,26 +1,39 @@
 package config
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"os"
 	"path"
+	"strconv"
 	"strings"
+
+	"github.com/reedom/convergen/v8/pkg/logger"
 )
 
-// Usage prints the usage of the tool.
-func Usage() {
+// usage prints the usage of the tool and the flags in the set.
+func usage(fs *flag.FlagSet) {
 	var sb strings.Builder
-	sb.WriteString("\nUsage: convergen [flags] <input path>\n\n")
-	sb.WriteString("By default, the generated code is written to <input path>.gen.go\n\n")
+	sb.WriteString("\nUsage: convergen [flags] <input path>\n")
+	sb.WriteString("       convergen [flags] <package pattern>...\n\n")
+	sb.WriteString("By default, the generated code is written to <input path>.gen.go\n")
+	sb.WriteString("With package patterns such as ./..., every file that has the convergen build tag\n")
+	sb.WriteString("and convergen interfaces in the packages is processed, and <file>.gen.go is written for each.\n\n")
 	sb.WriteString("Flags:\n")
-	_, _ = fmt.Fprint(os.Stderr, sb.String())
-	flag.PrintDefaults()
+	_, _ = fmt.Fprint(fs.Output(), sb.String())
+	fs.PrintDefaults()
 }
 
+// ErrNoInput is returned by ParseArgs if neither an input path nor package patterns are given.
+var ErrNoInput = errors.New("no input path or package pattern is given")
+
 type Config struct {
 	// Input is the path of the input file.
 	Input string
+	// Patterns are the package patterns to look for input files in, such as "./...".
+	// If not empty, Input and Output are ignored.
+	Patterns []string
 	// Output is the path where the generated code will be saved.
 	// If empty, the generated code will be saved in the same directory as
 	// the input file with the name "<basename>.gen.go".
@@ -31,6 +44,11 @@ type Config struct {
 	DryRun bool
 	// Prints instructs convergen to print the generated code to stdout.
 	Prints bool
+	// DiagFormat is the format to write the errors and warnings to stderr in.
+	DiagFormat logger.Format
+	// Check instructs convergen to compare the generated code with the existing output files
+	// instead of writing them, and to print the differences to stdout.
+	Check bool
 }
 
 // String returns the string representation of the config.
@@ -38,47 +56,87 @@ func (c *Config) String() string {
 	var sb strings.Builder
 	sb.WriteString("config.Config{\n\tInput: \"")
 	sb.WriteString(c.Input)
+	sb.WriteString("\"\n\tPatterns: \"")
+	sb.WriteString(strings.Join(c.Patterns, " "))
 	sb.WriteString("\"\n\tOutput: \"")
 	sb.WriteString(c.Output)
 	sb.WriteString("\"\n\tLog: \"")
 	sb.WriteString(c.Log)
-	sb.WriteString("\"\n}")
+	sb.WriteString("\"\n\tDryRun: ")
+	sb.WriteString(strconv.FormatBool(c.DryRun))
+	sb.WriteString("\n\tPrints: ")
+	sb.WriteString(strconv.FormatBool(c.Prints))
+	sb.WriteString("\n\tDiagFormat: \"")
+	sb.WriteString(string(c.DiagFormat))
+	sb.WriteString("\"\n\tCheck: ")
+	sb.WriteString(strconv.FormatBool(c.Check))
+	sb.WriteString("\n}")
 	return sb.String()
 }
 
-// ParseArgs parses the command line arguments.
-func (c *Config) ParseArgs() error {
-	output := flag.String("out", "", "Set the output file path")
-	logs := flag.Bool("log", false, "Write log messages to <output path>.log.")
-	dryRun := flag.Bool("dry", false, "Perform a dry run without writing files.")
-	prints := flag.Bool("print", false, "Print the resulting code to STDOUT as well.")
+// ParseArgs parses the command line arguments, excluding the command name.
+// It prints the usage to stderr and returns flag.ErrHelp if -h or -help is given,
+// or ErrNoInput if no input is given.
+func (c *Config) ParseArgs(arguments []string) error {
+	fs := flag.NewFlagSet("convergen", flag.ContinueOnError)
+	output := fs.String("out", "", "Set the output file path")
+	logs := fs.Bool("log", false, "Write log messages to <output path>.log, or convergen.log with package patterns.")
+	dryRun := fs.Bool("dry", false, "Perform a dry run without writing files.")
+	prints := fs.Bool("print", false, "Print the resulting code to STDOUT as well.")
+	check := fs.Bool("check", false, "Check the output files are up to date without writing, and print the differences.")
+	diag := fs.String("diag", string(logger.FormatText), "Write errors and warnings to STDERR in the format: text, json or sarif.")
 
-	flag.Usage = Usage
-	flag.Parse()
+	fs.Usage = func() { usage(fs) }
+	if err := fs.Parse(arguments); err != nil {
+		return err
+	}
 
-	inputPath := flag.Arg(0)
-	if inputPath == "" {
-		inputPath = os.Getenv("GOFILE")
+	args := fs.Args()
+	if len(args) == 0 {
+		if gofile := os.Getenv("GOFILE"); gofile != "" {
+			args = []string{gofile}
+		}
 	}
-	if inputPath == "" {
-		flag.Usage()
-		os.Exit(1)
+	if len(args) == 0 {
+		fs.Usage()
+		return ErrNoInput
 	}
-	c.Input = inputPath
 
-	if *output != "" {
-		c.Output = *output
-	} else {
-		ext := path.Ext(inputPath)
-		c.Output = inputPath[0:len(inputPath)-len(ext)] + ".gen" + ext
+	diagFormat, err := logger.ParseFormat(*diag)
+	if err != nil {
+		return err
 	}
 
-	if *logs {
-		ext := path.Ext(c.Output)
-		c.Log = c.Output[0:len(c.Output)-len(ext)] + ".log"
+	if len(args) == 1 && strings.HasSuffix(args[0], ".go") {
+		c.Input = args[0]
+		if *output != "" {
+			c.Output = *output
+		} else {
+			c.Output = OutputPath(c.Input)
+		}
+		if *logs {
+			ext := path.Ext(c.Output)
+			c.Log = c.Output[0:len(c.Output)-len(ext)] + ".log"
+		}
+	} else {
+		if *output != "" {
+			return fmt.Errorf("-out cannot be used with package patterns")
+		}
+		c.Patterns = args
+		if *logs {
+			c.Log = "convergen.log"
+		}
 	}
 	c.DryRun = *dryRun
 	c.Prints = *prints
+	c.Check = *check
+	c.DiagFormat = diagFormat
 
 	return nil
 }
+
+// OutputPath returns the default output path for the input path, i.e. "<basename>.gen.go".
+func OutputPath(inputPath string) string {
+	ext := path.Ext(inputPath)
+	return inputPath[0:len(inputPath)-len(ext)] + ".gen" + ext
+}
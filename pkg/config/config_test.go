@@ -0,0 +1,76 @@
+package config_test
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/reedom/convergen/v8/pkg/config"
+	"github.com/reedom/convergen/v8/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_ParseArgs(t *testing.T) {
+	t.Setenv("GOFILE", "")
+
+	var c config.Config
+	err := c.ParseArgs([]string{"-log", "-dry", "dir/setup.go"})
+	if assert.Nil(t, err) {
+		assert.Equal(t, "dir/setup.go", c.Input)
+		assert.Equal(t, "dir/setup.gen.go", c.Output)
+		assert.Equal(t, "dir/setup.gen.log", c.Log)
+		assert.Equal(t, logger.FormatText, c.DiagFormat)
+		assert.True(t, c.DryRun)
+		assert.False(t, c.Check)
+	}
+
+	c = config.Config{}
+	err = c.ParseArgs([]string{"-check", "-diag", "sarif", "./...", "./cmd"})
+	if assert.Nil(t, err) {
+		assert.Equal(t, []string{"./...", "./cmd"}, c.Patterns)
+		assert.Equal(t, "", c.Input)
+		assert.True(t, c.Check)
+		assert.Equal(t, logger.FormatSARIF, c.DiagFormat)
+	}
+
+	c = config.Config{}
+	err = c.ParseArgs([]string{"-diag", "xml", "./..."})
+	assert.NotNil(t, err)
+
+	c = config.Config{}
+	err = c.ParseArgs([]string{"-out", "out.go", "./..."})
+	assert.NotNil(t, err)
+
+	c = config.Config{}
+	err = c.ParseArgs(nil)
+	assert.ErrorIs(t, err, config.ErrNoInput)
+
+	t.Setenv("GOFILE", "setup.go")
+	c = config.Config{}
+	err = c.ParseArgs(nil)
+	if assert.Nil(t, err) {
+		assert.Equal(t, "setup.go", c.Input)
+	}
+
+	c = config.Config{}
+	err = c.ParseArgs([]string{"-h"})
+	assert.ErrorIs(t, err, flag.ErrHelp)
+}
+
+func TestConfig_String(t *testing.T) {
+	c := config.Config{
+		Input:      "setup.go",
+		Output:     "setup.gen.go",
+		DryRun:     true,
+		DiagFormat: logger.FormatJSON,
+	}
+	assert.Equal(t, `config.Config{
+	Input: "setup.go"
+	Patterns: ""
+	Output: "setup.gen.go"
+	Log: ""
+	DryRun: true
+	Prints: false
+	DiagFormat: "json"
+	Check: false
+}`, c.String())
+}
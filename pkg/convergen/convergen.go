@@ -0,0 +1,192 @@
+// Package convergen runs the convergen code generator in-process.
+//
+// Unlike the convergen command, Generate never writes to the disk, the standard output or
+// the standard error, and never exits the process. It returns the generated code and the
+// diagnostics instead, so that the caller can decide what to do with them.
+// Generate is safe to call concurrently.
+package convergen
+
+import (
+	"errors"
+	"go/token"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/reedom/convergen/v8/pkg/config"
+	"github.com/reedom/convergen/v8/pkg/generator"
+	"github.com/reedom/convergen/v8/pkg/generator/model"
+	"github.com/reedom/convergen/v8/pkg/logger"
+	"github.com/reedom/convergen/v8/pkg/parser"
+)
+
+// Diagnostic represents an error or a warning that convergen reports.
+type Diagnostic = logger.Diagnostic
+
+// Request specifies the setup files to generate the code from, and how to load them.
+type Request struct {
+	// Input is the path of the setup file.
+	Input string
+	// Output is the path of the file that the code is generated for.
+	// If empty, it is "<basename>.gen.go" next to the setup file.
+	// The file at the path is excluded from the package while loading, as it is about to be replaced.
+	Output string
+	// Patterns are the package patterns to look for setup files in, such as "./...".
+	// If not empty, Input and Output are ignored, and the output path of each setup file is
+	// "<basename>.gen.go" next to it.
+	Patterns []string
+	// Dir is the directory to resolve Input, Output and Patterns in.
+	// If empty, the current directory is used.
+	Dir string
+	// Overlay maps absolute file paths to their contents, which are read in place of the files on disk.
+	// A setup file in the overlay need not exist on disk.
+	Overlay map[string][]byte
+	// Log is the destination of the verbose log messages. If nil, they are discarded.
+	Log io.Writer
+}
+
+// File represents the code generated from a setup file.
+type File struct {
+	// Input is the absolute path of the setup file.
+	Input string
+	// Output is the path of the file that the code is generated for.
+	Output string
+	// Code is the generated code, formatted.
+	Code []byte
+}
+
+// Result represents the result of Generate.
+type Result struct {
+	// Files are the generated code, in the order of the setup file paths.
+	// If Generate fails, they are the code of the setup files that have no errors.
+	Files []File
+	// Diagnostics are the errors and warnings reported while generating the code.
+	Diagnostics []Diagnostic
+}
+
+// Generate generates the code from the setup files that req specifies, and returns it.
+// It checks every setup file, interface and method even after an error, so that the diagnostics
+// cover all the problems at once.
+// If it fails, it returns the error along with the result that holds the diagnostics,
+// which always include at least one error.
+func Generate(req Request) (*Result, error) {
+	var logOpts []logger.LoggerOpt
+	if req.Log != nil {
+		logOpts = append(logOpts, logger.Output(req.Log))
+	}
+	log := logger.New(logOpts...)
+
+	files, err := generate(req, log)
+	if err != nil && !log.HasErrors() {
+		// Such as an I/O error, or the generated code that fails to be formatted.
+		_ = log.Report(logger.Diagnostic{
+			Severity: logger.SeverityError,
+			Code:     logger.CodeGenerateFailed,
+			Message:  err.Error(),
+		})
+	}
+	return &Result{
+		Files:       files,
+		Diagnostics: log.Diagnostics(),
+	}, err
+}
+
+// generate generates the code from the setup files that req specifies.
+func generate(req Request, log *logger.Logger) ([]File, error) {
+	options := []parser.ParserOpt{
+		parser.Dir(req.Dir),
+		parser.Overlay(req.Overlay),
+		parser.Logger(log),
+	}
+
+	if 0 < len(req.Patterns) {
+		parsers, err := parser.NewPackageParsers(req.Patterns, options...)
+		if err != nil {
+			return nil, err
+		}
+		if len(parsers) == 0 {
+			log.WarnAt(token.Position{}, logger.CodeNotFound, "%v: no convergen setup files found", strings.Join(req.Patterns, " "))
+			return nil, nil
+		}
+
+		files := make([]File, 0, len(parsers))
+		var errs []error
+		for _, p := range parsers {
+			file, err := generateFile(p, config.OutputPath(p.SrcPath()))
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			files = append(files, file)
+		}
+		return files, errors.Join(errs...)
+	}
+
+	outPath := req.Output
+	if outPath == "" {
+		outPath = config.OutputPath(req.Input)
+	}
+	if !filepath.IsAbs(outPath) && req.Dir != "" {
+		outPath = filepath.Join(req.Dir, outPath)
+	}
+
+	p, err := parser.NewParser(req.Input, outPath, options...)
+	if err != nil {
+		return nil, err
+	}
+	file, err := generateFile(p, outPath)
+	if err != nil {
+		return nil, err
+	}
+	return []File{file}, nil
+}
+
+// generateFile generates the code from the setup file that p parses.
+// It creates a function builder to build a block of functions for each set of methods,
+// and combines them with the base code of the setup file.
+// If some methods fail to be parsed, it still builds the rest to report their errors too.
+func generateFile(p *parser.Parser, outPath string) (File, error) {
+	methods, err := p.Parse()
+	errs := []error{err}
+
+	builder := p.CreateBuilder()
+
+	var funcBlocks []model.FunctionsBlock
+	for _, info := range methods {
+		functions, err := builder.CreateFunctions(info.Methods)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		block := model.FunctionsBlock{
+			Marker:    info.Marker,
+			Functions: functions,
+		}
+		funcBlocks = append(funcBlocks, block)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return File{}, err
+	}
+
+	baseCode, err := p.GenerateBaseCode()
+	if err != nil {
+		return File{}, err
+	}
+
+	code := model.Code{
+		BaseCode:       baseCode,
+		FunctionBlocks: funcBlocks,
+	}
+
+	g := generator.NewGenerator(code)
+	generated, err := g.Generate(outPath, false, true)
+	if err != nil {
+		return File{}, err
+	}
+
+	return File{
+		Input:  p.SrcPath(),
+		Output: outPath,
+		Code:   generated,
+	}, nil
+}
@@ -0,0 +1,294 @@
+package convergen_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/reedom/convergen/v8/pkg/convergen"
+	"github.com/reedom/convergen/v8/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fixtureDir = "../../tests/fixtures/usecase/checkedcastnoerr"
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	dir, err := filepath.Abs(fixtureDir)
+	require.Nil(t, err)
+	setupPath := filepath.Join(dir, "setup.go")
+	setup, err := os.ReadFile(setupPath)
+	require.Nil(t, err)
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := convergen.Generate(convergen.Request{Input: "setup.go", Dir: dir})
+		assert.NotNil(t, err)
+		assert.Empty(t, result.Files)
+		require.NotEmpty(t, result.Diagnostics)
+		assert.Equal(t, logger.SeverityError, result.Diagnostics[0].Severity)
+		assert.Equal(t, logger.CodeErrorReturnRequired, result.Diagnostics[0].Code)
+		assert.Equal(t, `":typecast checked" requires the method to return an error`, result.Diagnostics[0].Message)
+		assert.Equal(t, filepath.Join(dir, "setup.go"), result.Diagnostics[0].Pos.Filename)
+		assert.Equal(t, 16, result.Diagnostics[0].Pos.Line)
+	})
+
+	t.Run("every error", func(t *testing.T) {
+		t.Parallel()
+
+		src := strings.Replace(string(setup), `	ToModel(*Stats) *StatsModel
+`, `	ToModel(*Stats) *StatsModel
+	// :match unknown
+	ToModelByMatch(*Stats) *StatsModel
+	ToModelFromInt(int) *StatsModel
+	// :typecast
+	ToModelByTypecast(*Stats) *StatsModel
+`, 1)
+		result, err := convergen.Generate(convergen.Request{
+			Input:   setupPath,
+			Overlay: map[string][]byte{setupPath: []byte(src)},
+		})
+		assert.NotNil(t, err)
+		assert.Empty(t, result.Files)
+
+		// The errors from the parser and the builder are reported together.
+		var actual []string
+		for _, d := range result.Diagnostics {
+			assert.Equal(t, logger.SeverityError, d.Severity)
+			actual = append(actual, fmt.Sprintf("%v: %v", d.Pos.Line, d.Code))
+		}
+		assert.Equal(t, []string{
+			"16: error-return-required",
+			"17: invalid-argument",
+			"19: invalid-method",
+		}, actual)
+	})
+
+	t.Run("overlay", func(t *testing.T) {
+		t.Parallel()
+
+		src := strings.Replace(string(setup), ":typecast checked", ":typecast", 1)
+		result, err := convergen.Generate(convergen.Request{
+			Input:   setupPath,
+			Overlay: map[string][]byte{setupPath: []byte(src)},
+		})
+		require.Nil(t, err)
+		assert.Empty(t, result.Diagnostics)
+		require.Len(t, result.Files, 1)
+		assert.Equal(t, setupPath, result.Files[0].Input)
+		assert.Equal(t, filepath.Join(dir, "setup.gen.go"), result.Files[0].Output)
+		assert.Contains(t, string(result.Files[0].Code), "dst.Count = int32(src.Count)")
+
+		_, err = os.Stat(result.Files[0].Output)
+		assert.True(t, os.IsNotExist(err), "Generate must not write the output file")
+	})
+
+	t.Run("overlay only", func(t *testing.T) {
+		t.Parallel()
+
+		extraPath := filepath.Join(dir, "extra.go")
+		src := `//go:build convergen
+
+package checkedcastnoerr
+
+// :convergen
+type Extra interface {
+	// :typecast
+	ToExtra(*Stats) *StatsModel
+}
+`
+		result, err := convergen.Generate(convergen.Request{
+			Patterns: []string{"."},
+			Dir:      dir,
+			Overlay: map[string][]byte{
+				setupPath: []byte(strings.Replace(string(setup), ":typecast checked", ":typecast", 1)),
+				extraPath: []byte(src),
+			},
+		})
+		require.Nil(t, err)
+		require.Len(t, result.Files, 2)
+		assert.Equal(t, extraPath, result.Files[0].Input)
+		assert.Equal(t, filepath.Join(dir, "extra.gen.go"), result.Files[0].Output)
+		assert.Contains(t, string(result.Files[0].Code), "func ToExtra(src *Stats) (dst *StatsModel) {")
+		assert.Equal(t, setupPath, result.Files[1].Input)
+	})
+}
+
+func TestGenerate_CopierRequiresErrorReturn(t *testing.T) {
+	t.Parallel()
+
+	dir, err := filepath.Abs(fixtureDir)
+	require.Nil(t, err)
+	setupPath := filepath.Join(dir, "setup.go")
+	src := `//go:build convergen
+
+package checkedcastnoerr
+
+type Src struct {
+	Items []Item
+}
+
+type Dst struct {
+	Items []*DstItem
+}
+
+type Item struct {
+	Count string
+}
+
+type DstItem struct {
+	Count int
+}
+
+type Convergen interface {
+	// :builtin strconv
+	ToDst(*Src) *Dst
+}
+`
+	result, err := convergen.Generate(convergen.Request{
+		Input:   setupPath,
+		Overlay: map[string][]byte{setupPath: []byte(src)},
+	})
+	require.Nil(t, err)
+	require.Len(t, result.Files, 1)
+	// The copier returns an error for strconv.Atoi, which ToDst cannot return.
+	assert.NotContains(t, string(result.Files[0].Code), "= copyItemToDstItem(&e)")
+
+	var codes []logger.Code
+	for _, d := range result.Diagnostics {
+		assert.Equal(t, logger.SeverityWarning, d.Severity)
+		codes = append(codes, d.Code)
+	}
+	assert.Equal(t, []logger.Code{logger.CodeErrorReturnRequired, logger.CodeNoAssignment}, codes)
+	assert.Equal(t, "copying Item to *DstItem by copyItemToDstItem requires the function to return an error",
+		result.Diagnostics[0].Message)
+}
+
+func TestGenerate_InvalidFlattenRule(t *testing.T) {
+	t.Parallel()
+
+	dir, err := filepath.Abs(fixtureDir)
+	require.Nil(t, err)
+	setupPath := filepath.Join(dir, "setup.go")
+	src := `//go:build convergen
+
+package checkedcastnoerr
+
+type Src struct {
+	Name    string
+	Address string
+}
+
+type Dst struct {
+	Name        string
+	AddressCity string
+	AddressZip  string
+}
+
+type Convergen interface {
+	// :flatten Address
+	// :unflatten Name
+	ToDst(*Src) *Dst
+}
+`
+	result, err := convergen.Generate(convergen.Request{
+		Input:   setupPath,
+		Overlay: map[string][]byte{setupPath: []byte(src)},
+	})
+	assert.NotNil(t, err)
+
+	// Each invalid rule is reported once, not once per destination field.
+	var actual []string
+	for _, d := range result.Diagnostics {
+		if d.Severity == logger.SeverityError {
+			actual = append(actual, fmt.Sprintf("%v: %v", d.Pos.Line, d.Message))
+		}
+	}
+	assert.Equal(t, []string{
+		"17: src.Address is not a struct field",
+		"18: dst.Name is not a struct field",
+	}, actual)
+}
+
+func TestGenerate_GeneratedMethodAsConverter(t *testing.T) {
+	t.Parallel()
+
+	dir, err := filepath.Abs("../../tests/fixtures/usecase/variant")
+	require.Nil(t, err)
+
+	// CardToModel is a method to be generated, so it must not be reported as not found.
+	result, err := convergen.Generate(convergen.Request{Input: "setup.go", Dir: dir})
+	require.Nil(t, err)
+	assert.Empty(t, result.Diagnostics)
+	require.Len(t, result.Files, 1)
+}
+
+func TestGenerate_EnumCaseAfterEnum(t *testing.T) {
+	t.Parallel()
+
+	dir, err := filepath.Abs(fixtureDir)
+	require.Nil(t, err)
+	setupPath := filepath.Join(dir, "setup.go")
+	src := `//go:build convergen
+
+package checkedcastnoerr
+
+type Color int
+
+const (
+	ColorRED Color = iota
+	ColorBLUE
+)
+
+type Paint int
+
+const (
+	PaintRed Paint = iota
+	PaintBlue
+)
+
+type Src struct {
+	Color Color
+}
+
+type Dst struct {
+	Color Paint
+}
+
+type Convergen interface {
+	// :enum Color Paint Color* Paint*
+	// %v
+	ToDst(*Src) *Dst
+}
+`
+	// The constants are paired by the case setting after all the notations of the method,
+	// not by the one at the time of ":enum".
+	for _, tt := range []struct {
+		notation string
+		wantErr  bool
+	}{
+		{notation: ":case:off", wantErr: false},
+		{notation: ":case", wantErr: true},
+	} {
+		t.Run(tt.notation, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := convergen.Generate(convergen.Request{
+				Input:   setupPath,
+				Overlay: map[string][]byte{setupPath: []byte(fmt.Sprintf(src, tt.notation))},
+			})
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Len(t, result.Files, 1)
+			assert.Contains(t, string(result.Files[0].Code), "case ColorRED:")
+		})
+	}
+}
@@ -82,6 +82,7 @@ func (g *Generator) FuncToString(f *model.Function) string {
 
 		// "func Name(src *SrcModel) (dst *DstModel) {"
 		sb.WriteString(") {\n")
+		writeNilCheck(&sb, f)
 		if f.Dst.Pointer {
 			// "dst = &DstModel{}"
 			sb.WriteString(f.Dst.Name)
@@ -100,6 +101,7 @@ func (g *Generator) FuncToString(f *model.Function) string {
 			// "func Name(dst *DstModel, src *SrcModel) {"
 			sb.WriteString("{\n")
 		}
+		writeNilCheck(&sb, f)
 	}
 
 	if f.PreProcess != nil {
@@ -117,3 +119,14 @@ func (g *Generator) FuncToString(f *model.Function) string {
 	sb.WriteString("}\n\n")
 	return sb.String()
 }
+
+// writeNilCheck writes a statement that returns immediately if the source is nil.
+func writeNilCheck(sb *strings.Builder, f *model.Function) {
+	if !f.NilCheck || !f.Src.Pointer {
+		return
+	}
+	// "if src == nil {"
+	sb.WriteString("if ")
+	sb.WriteString(f.Src.Name)
+	sb.WriteString(" == nil {\nreturn\n}\n")
+}
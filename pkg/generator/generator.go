@@ -2,11 +2,14 @@ package generator
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"go/format"
+	"io/fs"
 	"os"
 	"strings"
 
+	"github.com/pmezard/go-difflib/difflib"
 	"github.com/reedom/convergen/v8/pkg/generator/model"
 	"golang.org/x/tools/imports"
 )
@@ -62,6 +65,27 @@ func (g *Generator) Generate(outPath string, output, dryRun bool) ([]byte, error
 	return formatted, nil
 }
 
+// Diff compares the generated code with the file at outPath.
+// It returns the differences in unified diff format, or "" if the file is up to date.
+// A missing file is compared as an empty one. Diff never writes to the disk.
+func Diff(outPath string, generated []byte) (string, error) {
+	current, err := os.ReadFile(outPath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("error on reading the file.\n%w", err)
+	}
+	if bytes.Equal(current, generated) {
+		return "", nil
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(current)),
+		B:        difflib.SplitLines(string(generated)),
+		FromFile: outPath,
+		ToFile:   outPath + " (generated)",
+		Context:  3,
+	})
+}
+
 // generateContent generates the entire code with the given information.
 func (g *Generator) generateContent() (content []byte, err error) {
 	code := g.code.BaseCode
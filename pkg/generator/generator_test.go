@@ -1,6 +1,8 @@
 package generator_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/reedom/convergen/v8/pkg/generator"
@@ -356,3 +358,58 @@ func ToModel(src *domain.Pet) (dst *model.Pet, err error) {
 		})
 	}
 }
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	code := model.Code{
+		BaseCode: pre + "xxxxx",
+		FunctionBlocks: []model.FunctionsBlock{
+			{
+				Marker: "xxxxx",
+				Functions: []*model.Function{
+					{
+						Name:        "ToModel",
+						Src:         model.Var{Name: "src", Type: "domain.Pet", Pointer: true},
+						Dst:         model.Var{Name: "dst", Type: "model.Pet", Pointer: true},
+						DstVarStyle: model.DstVarArg,
+						Assignments: []model.Assignment{
+							model.SimpleField{LHS: "dst.ID", RHS: "src.ID"},
+						},
+					},
+				},
+			},
+		},
+	}
+	g := generator.NewGenerator(code)
+	expected, err := g.Generate("temp.gen.go", false, true)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	dir := t.TempDir()
+	outPath := filepath.Join(dir, "temp.gen.go")
+
+	// A missing file differs in every line.
+	diff, err := generator.Diff(outPath, expected)
+	assert.Nil(t, err)
+	assert.Contains(t, diff, "+func ToModel(dst *model.Pet, src *domain.Pet) {")
+
+	// An up-to-date file has no differences.
+	assert.Nil(t, os.WriteFile(outPath, expected, 0644))
+	diff, err = generator.Diff(outPath, expected)
+	assert.Nil(t, err)
+	assert.Equal(t, "", diff)
+
+	// An edited file shows the differences, and is left as it is.
+	edited := append([]byte("// edited\n"), expected...)
+	assert.Nil(t, os.WriteFile(outPath, edited, 0644))
+	diff, err = generator.Diff(outPath, expected)
+	assert.Nil(t, err)
+	assert.Contains(t, diff, "--- "+outPath+"\n")
+	assert.Contains(t, diff, "+++ "+outPath+" (generated)\n")
+	assert.Contains(t, diff, "-// edited\n")
+	actual, err := os.ReadFile(outPath)
+	assert.Nil(t, err)
+	assert.Equal(t, edited, actual)
+}
@@ -1,9 +1,13 @@
 package model
 
 import (
+	"strconv"
 	"strings"
 )
 
+// breakOnError is the statement that exits a loop when an error occurs in it.
+const breakOnError = "if err != nil {\nbreak\n}\n"
+
 // Assignment represents an assignment between fields in a struct.
 type Assignment interface {
 	// String returns the string representation of the assignment.
@@ -203,3 +207,400 @@ func (c SliceTypecastAssignment) String() string {
 func (c SliceTypecastAssignment) RetError() bool {
 	return false
 }
+
+// MapAssignment represents a map assignment with a loop.
+// Key and Value are the expressions to store into the destination map,
+// and they refer the source entry through "k" and "v" respectively.
+// If Error is true, Value returns an error as the second returning value and
+// the loop breaks on the error.
+type MapAssignment struct {
+	LHS   string
+	RHS   string
+	Typ   string
+	Key   string
+	Value string
+	Error bool
+}
+
+// String returns the string representation of the map assignment.
+func (c MapAssignment) String() string {
+	var sb strings.Builder
+	sb.WriteString("if ")
+	sb.WriteString(c.RHS)
+	sb.WriteString(" != nil {\n")
+	sb.WriteString(c.LHS)
+	sb.WriteString(" = make(")
+	sb.WriteString(c.Typ)
+	sb.WriteString(", len(")
+	sb.WriteString(c.RHS)
+	sb.WriteString("))\nfor k, v := range ")
+	sb.WriteString(c.RHS)
+	sb.WriteString(" {\n")
+	sb.WriteString(c.LHS)
+	sb.WriteString("[")
+	sb.WriteString(c.Key)
+	sb.WriteString("]")
+	if c.Error {
+		sb.WriteString(", err")
+	}
+	sb.WriteString(" = ")
+	sb.WriteString(c.Value)
+	sb.WriteString("\n")
+	if c.Error {
+		sb.WriteString(breakOnError)
+	}
+	sb.WriteString("}\n}\n")
+	return sb.String()
+}
+
+// RetError returns whether the assignment returns an error value.
+func (c MapAssignment) RetError() bool {
+	return c.Error
+}
+
+// SliceConvAssignment represents a slice assignment with a loop that converts each element.
+// Elem is the expression to store into the destination slice, and it refers the source
+// element through "e".
+// If Error is true, Elem returns an error as the second returning value and
+// the loop breaks on the error.
+type SliceConvAssignment struct {
+	LHS   string
+	RHS   string
+	Typ   string
+	Elem  string
+	Error bool
+}
+
+// String returns the string representation of the slice assignment with a conversion.
+func (c SliceConvAssignment) String() string {
+	var sb strings.Builder
+	sb.WriteString("if ")
+	sb.WriteString(c.RHS)
+	sb.WriteString(" != nil {\n")
+	sb.WriteString(c.LHS)
+	sb.WriteString(" = make(")
+	sb.WriteString(c.Typ)
+	sb.WriteString(", len(")
+	sb.WriteString(c.RHS)
+	sb.WriteString("))\nfor i, e := range ")
+	sb.WriteString(c.RHS)
+	sb.WriteString(" {\n")
+	sb.WriteString(c.LHS)
+	sb.WriteString("[i]")
+	if c.Error {
+		sb.WriteString(", err")
+	}
+	sb.WriteString(" = ")
+	sb.WriteString(c.Elem)
+	sb.WriteString("\n")
+	if c.Error {
+		sb.WriteString(breakOnError)
+	}
+	sb.WriteString("}\n}\n")
+	return sb.String()
+}
+
+// RetError returns whether the assignment returns an error value.
+// The loop breaks on an error so that the caller can handle it after the loop.
+func (c SliceConvAssignment) RetError() bool {
+	return c.Error
+}
+
+// ArrayConvAssignment represents an array assignment with a loop that converts each element.
+// Elem is the expression to store into the destination array, and it refers the source
+// element through "e".
+// If Error is true, Elem returns an error as the second returning value and
+// the loop breaks on the error.
+type ArrayConvAssignment struct {
+	LHS   string
+	RHS   string
+	Elem  string
+	Error bool
+}
+
+// String returns the string representation of the array assignment with a conversion.
+func (c ArrayConvAssignment) String() string {
+	var sb strings.Builder
+	sb.WriteString("for i, e := range ")
+	sb.WriteString(c.RHS)
+	sb.WriteString(" {\n")
+	sb.WriteString(c.LHS)
+	sb.WriteString("[i]")
+	if c.Error {
+		sb.WriteString(", err")
+	}
+	sb.WriteString(" = ")
+	sb.WriteString(c.Elem)
+	sb.WriteString("\n")
+	if c.Error {
+		sb.WriteString(breakOnError)
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// RetError returns whether the assignment returns an error value.
+func (c ArrayConvAssignment) RetError() bool {
+	return c.Error
+}
+
+// ArrayToSliceAssignment represents a slice assignment from an array with a loop that converts each element.
+// Elem is the expression to store into the destination slice, and it refers the source
+// element through "e".
+// If Error is true, Elem returns an error as the second returning value and
+// the loop breaks on the error.
+type ArrayToSliceAssignment struct {
+	LHS   string
+	RHS   string
+	Typ   string
+	Elem  string
+	Error bool
+}
+
+// String returns the string representation of the slice assignment from an array.
+func (c ArrayToSliceAssignment) String() string {
+	var sb strings.Builder
+	sb.WriteString(c.LHS)
+	sb.WriteString(" = make(")
+	sb.WriteString(c.Typ)
+	sb.WriteString(", len(")
+	sb.WriteString(c.RHS)
+	sb.WriteString("))\n")
+	sb.WriteString(ArrayConvAssignment{LHS: c.LHS, RHS: c.RHS, Elem: c.Elem, Error: c.Error}.String())
+	return sb.String()
+}
+
+// RetError returns whether the assignment returns an error value.
+func (c ArrayToSliceAssignment) RetError() bool {
+	return c.Error
+}
+
+// SliceToArrayAssignment represents an array assignment from a slice with a loop that converts each element.
+// If the length of the slice differs from Len, the assignment sets an error instead.
+// Elem is the expression to store into the destination array, and it refers the source
+// element through "e".
+// If Error is true, Elem returns an error as the second returning value and
+// the loop breaks on the error.
+type SliceToArrayAssignment struct {
+	LHS   string
+	RHS   string
+	Len   int64
+	Elem  string
+	Error bool
+}
+
+// String returns the string representation of the array assignment from a slice.
+func (c SliceToArrayAssignment) String() string {
+	length := strconv.FormatInt(c.Len, 10)
+	var sb strings.Builder
+	sb.WriteString("if len(")
+	sb.WriteString(c.RHS)
+	sb.WriteString(") != ")
+	sb.WriteString(length)
+	sb.WriteString(" {\nerr = fmt.Errorf(")
+	sb.WriteString(strconv.Quote(c.RHS + " has %d elements, but " + c.LHS + " needs " + length))
+	sb.WriteString(", len(")
+	sb.WriteString(c.RHS)
+	sb.WriteString("))\n} else {\n")
+	sb.WriteString(ArrayConvAssignment{LHS: c.LHS, RHS: c.RHS, Elem: c.Elem, Error: c.Error}.String())
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// RetError always returns true since the length check may fail.
+func (c SliceToArrayAssignment) RetError() bool {
+	return true
+}
+
+// PointerConvAssignment represents an assignment that converts the value a pointer refers to
+// and stores the address of the result.
+// Elem is the expression that converts the value, and Typ is the type of its result.
+// Elem returns an error as the second returning value, so that the result is stored into
+// a variable before its address is taken.
+type PointerConvAssignment struct {
+	LHS  string
+	RHS  string
+	Typ  string
+	Elem string
+}
+
+// String returns the string representation of the pointer assignment with a conversion.
+func (c PointerConvAssignment) String() string {
+	var sb strings.Builder
+	sb.WriteString("if ")
+	sb.WriteString(c.RHS)
+	sb.WriteString(" != nil {\nvar v ")
+	sb.WriteString(c.Typ)
+	sb.WriteString("\nv, err = ")
+	sb.WriteString(c.Elem)
+	sb.WriteString("\n")
+	sb.WriteString(c.LHS)
+	sb.WriteString(" = &v\n}\n")
+	return sb.String()
+}
+
+// RetError always returns true since Elem returns an error.
+func (c PointerConvAssignment) RetError() bool {
+	return true
+}
+
+// GuardedField represents an assignment that is executed only if all the conditions are met.
+// Fallback, if any, is executed otherwise.
+type GuardedField struct {
+	Conditions []string
+	Content    Assignment
+	Fallback   Assignment
+}
+
+// String returns the string representation of the guarded assignment.
+func (g GuardedField) String() string {
+	var sb strings.Builder
+	sb.WriteString("if ")
+	sb.WriteString(strings.Join(g.Conditions, " && "))
+	sb.WriteString(" {\n")
+	sb.WriteString(g.Content.String())
+	if g.Fallback != nil {
+		sb.WriteString("} else {\n")
+		sb.WriteString(g.Fallback.String())
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// RetError returns whether the guarded assignment returns an error value.
+func (g GuardedField) RetError() bool {
+	return g.Content.RetError() || (g.Fallback != nil && g.Fallback.RetError())
+}
+
+// ErrorField represents an assignment of an error that the function returns.
+type ErrorField struct {
+	Message string
+}
+
+// String returns the string representation of the error assignment.
+func (e ErrorField) String() string {
+	var sb strings.Builder
+	sb.WriteString("err = errors.New(")
+	sb.WriteString(strconv.Quote(e.Message))
+	sb.WriteString(")\n")
+	return sb.String()
+}
+
+// RetError always returns true for error assignments.
+func (e ErrorField) RetError() bool {
+	return true
+}
+
+// SwitchField represents an assignment that maps the value of an expression by a switch statement.
+// LHS keeps its value if none of the cases matches.
+type SwitchField struct {
+	LHS   string
+	Expr  string
+	Cases []SwitchCase
+}
+
+// SwitchCase represents a case of SwitchField.
+type SwitchCase struct {
+	Value string // Value is the case value to compare with the expression.
+	RHS   string // RHS is the value assigned to the LHS in the case.
+}
+
+// String returns the string representation of the switch assignment.
+func (s SwitchField) String() string {
+	var sb strings.Builder
+	sb.WriteString("switch ")
+	sb.WriteString(s.Expr)
+	sb.WriteString(" {\n")
+	for _, c := range s.Cases {
+		sb.WriteString("case ")
+		sb.WriteString(c.Value)
+		sb.WriteString(":\n")
+		sb.WriteString(s.LHS)
+		sb.WriteString(" = ")
+		sb.WriteString(c.RHS)
+		sb.WriteString("\n")
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// RetError always returns false for switch assignments.
+func (s SwitchField) RetError() bool {
+	return false
+}
+
+// TypeSwitchField represents an assignment that converts an interface value by its concrete type.
+// Each case refers the concrete value through "v". A nil value, including a nil pointer of
+// a concrete type, leaves LHS untouched, and a value of any other type results in an error.
+// A nil result of a case with RetType also leaves LHS untouched, so that LHS of an interface type
+// never holds a typed nil.
+type TypeSwitchField struct {
+	LHS     string
+	Expr    string
+	DstType string // DstType is the type expression of LHS that the error message refers.
+	Cases   []TypeSwitchCase
+}
+
+// TypeSwitchCase represents a case of TypeSwitchField.
+type TypeSwitchCase struct {
+	Type  string // Type is the concrete type of the case.
+	RHS   string // RHS is the value assigned to the LHS in the case.
+	Error bool   // Error indicates whether RHS returns an error as the second returning value.
+	Ptr   bool   // Ptr indicates whether Type is a pointer so that "v" may be nil.
+	// RetType is the type expression of the result of RHS if it may be a typed nil, or empty.
+	RetType string
+}
+
+// String returns the string representation of the type switch assignment.
+func (s TypeSwitchField) String() string {
+	var sb strings.Builder
+	sb.WriteString("switch v := ")
+	sb.WriteString(s.Expr)
+	sb.WriteString(".(type) {\ncase nil:\n")
+	for _, c := range s.Cases {
+		sb.WriteString("case ")
+		sb.WriteString(c.Type)
+		sb.WriteString(":\n")
+		if c.Ptr {
+			sb.WriteString("if v != nil {\n")
+		}
+		lhs, op := s.LHS, " = "
+		if c.RetType != "" {
+			lhs, op = "r", " := "
+			if c.Error {
+				sb.WriteString("var r ")
+				sb.WriteString(c.RetType)
+				sb.WriteString("\n")
+				op = " = "
+			}
+		}
+		sb.WriteString(lhs)
+		if c.Error {
+			sb.WriteString(", err")
+		}
+		sb.WriteString(op)
+		sb.WriteString(c.RHS)
+		sb.WriteString("\n")
+		if c.RetType != "" {
+			sb.WriteString("if r != nil {\n")
+			sb.WriteString(s.LHS)
+			sb.WriteString(" = r\n}\n")
+		}
+		if c.Ptr {
+			sb.WriteString("}\n")
+		}
+	}
+	sb.WriteString("default:\nerr = fmt.Errorf(")
+	sb.WriteString(strconv.Quote("cannot convert %T into " + s.DstType))
+	sb.WriteString(", ")
+	sb.WriteString(s.Expr)
+	sb.WriteString(")\n}\n")
+	return sb.String()
+}
+
+// RetError always returns false for type switch assignments.
+// A type switch is the last statement of a helper function so that the function returns
+// the error as it is, without checking it.
+func (s TypeSwitchField) RetError() bool {
+	return false
+}
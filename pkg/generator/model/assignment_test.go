@@ -144,3 +144,290 @@ foo[i] = string(e)
 		require.False(t, actual)
 	})
 }
+
+func TestMapAssignment(t *testing.T) {
+	t.Parallel()
+	ma := model.MapAssignment{
+		LHS:   "foo",
+		RHS:   "bar",
+		Typ:   "map[string]int64",
+		Key:   "k.String()",
+		Value: "int64(v)",
+	}
+
+	t.Run("String", func(t *testing.T) {
+		expected := `if bar != nil {
+foo = make(map[string]int64, len(bar))
+for k, v := range bar {
+foo[k.String()] = int64(v)
+}
+}
+`
+		actual := ma.String()
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("RetError", func(t *testing.T) {
+		actual := ma.RetError()
+		require.False(t, actual)
+	})
+}
+
+func TestSliceConvAssignment(t *testing.T) {
+	t.Parallel()
+	sca := model.SliceConvAssignment{
+		LHS:   "foo",
+		RHS:   "bar",
+		Typ:   "[]*Item",
+		Elem:  "copyItem(e)",
+		Error: true,
+	}
+
+	t.Run("String", func(t *testing.T) {
+		expected := `if bar != nil {
+foo = make([]*Item, len(bar))
+for i, e := range bar {
+foo[i], err = copyItem(e)
+if err != nil {
+break
+}
+}
+}
+`
+		actual := sca.String()
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("RetError", func(t *testing.T) {
+		actual := sca.RetError()
+		require.True(t, actual)
+	})
+}
+
+func TestArrayConvAssignment(t *testing.T) {
+	t.Parallel()
+	aca := model.ArrayConvAssignment{
+		LHS:   "foo",
+		RHS:   "bar",
+		Elem:  "toTag(e)",
+		Error: true,
+	}
+
+	t.Run("String", func(t *testing.T) {
+		expected := `for i, e := range bar {
+foo[i], err = toTag(e)
+if err != nil {
+break
+}
+}
+`
+		actual := aca.String()
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("RetError", func(t *testing.T) {
+		actual := aca.RetError()
+		require.True(t, actual)
+	})
+}
+
+func TestArrayToSliceAssignment(t *testing.T) {
+	t.Parallel()
+	asa := model.ArrayToSliceAssignment{
+		LHS:  "foo",
+		RHS:  "bar",
+		Typ:  "[]float32",
+		Elem: "float32(e)",
+	}
+
+	t.Run("String", func(t *testing.T) {
+		expected := `foo = make([]float32, len(bar))
+for i, e := range bar {
+foo[i] = float32(e)
+}
+`
+		actual := asa.String()
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("RetError", func(t *testing.T) {
+		actual := asa.RetError()
+		require.False(t, actual)
+	})
+}
+
+func TestSliceToArrayAssignment(t *testing.T) {
+	t.Parallel()
+	saa := model.SliceToArrayAssignment{
+		LHS:  "foo",
+		RHS:  "bar",
+		Len:  16,
+		Elem: "e",
+	}
+
+	t.Run("String", func(t *testing.T) {
+		expected := `if len(bar) != 16 {
+err = fmt.Errorf("bar has %d elements, but foo needs 16", len(bar))
+} else {
+for i, e := range bar {
+foo[i] = e
+}
+}
+`
+		actual := saa.String()
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("RetError", func(t *testing.T) {
+		actual := saa.RetError()
+		require.True(t, actual)
+	})
+}
+
+func TestPointerConvAssignment(t *testing.T) {
+	t.Parallel()
+
+	pca := model.PointerConvAssignment{
+		LHS:  "foo",
+		RHS:  "bar",
+		Typ:  "model.Tag",
+		Elem: "toTag(*bar)",
+	}
+	expected := `if bar != nil {
+var v model.Tag
+v, err = toTag(*bar)
+foo = &v
+}
+`
+	assert.Equal(t, expected, pca.String())
+	assert.True(t, pca.RetError())
+}
+
+func TestGuardedField(t *testing.T) {
+	t.Parallel()
+	gf := model.GuardedField{
+		Conditions: []string{"bar.Owner != nil", "bar.Owner.Address != nil"},
+		Content:    model.SimpleField{LHS: "foo.City", RHS: "bar.Owner.Address.City"},
+	}
+
+	t.Run("String", func(t *testing.T) {
+		expected := `if bar.Owner != nil && bar.Owner.Address != nil {
+foo.City = bar.Owner.Address.City
+}
+`
+		actual := gf.String()
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("String with Fallback", func(t *testing.T) {
+		gf := gf
+		gf.Fallback = model.SimpleField{LHS: "foo.City", RHS: `"unknown"`}
+		expected := `if bar.Owner != nil && bar.Owner.Address != nil {
+foo.City = bar.Owner.Address.City
+} else {
+foo.City = "unknown"
+}
+`
+		actual := gf.String()
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("RetError", func(t *testing.T) {
+		require.False(t, gf.RetError())
+
+		gf := gf
+		gf.Content = model.SimpleField{LHS: "foo.City", RHS: "parse(bar.Owner.Address.City)", Error: true}
+		require.True(t, gf.RetError())
+	})
+
+	t.Run("RetError with Fallback", func(t *testing.T) {
+		gf := gf
+		gf.Fallback = model.ErrorField{Message: "bar.Owner is nil"}
+		require.True(t, gf.RetError())
+	})
+}
+
+func TestErrorField(t *testing.T) {
+	t.Parallel()
+	ef := model.ErrorField{Message: `"bar" is nil`}
+
+	t.Run("String", func(t *testing.T) {
+		expected := "err = errors.New(\"\\\"bar\\\" is nil\")\n"
+		actual := ef.String()
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("RetError", func(t *testing.T) {
+		require.True(t, ef.RetError())
+	})
+}
+
+func TestSwitchField(t *testing.T) {
+	t.Parallel()
+	sf := model.SwitchField{
+		LHS:  "dst",
+		Expr: "src",
+		Cases: []model.SwitchCase{
+			{Value: "domain.StatusActive", RHS: "model.Active"},
+			{Value: "domain.StatusInactive", RHS: "model.Inactive"},
+		},
+	}
+
+	t.Run("String", func(t *testing.T) {
+		expected := "switch src {\ncase domain.StatusActive:\ndst = model.Active\ncase domain.StatusInactive:\ndst = model.Inactive\n}\n"
+		actual := sf.String()
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("RetError", func(t *testing.T) {
+		require.False(t, sf.RetError())
+	})
+}
+
+func TestTypeSwitchField(t *testing.T) {
+	t.Parallel()
+	tsf := model.TypeSwitchField{
+		LHS:     "dst",
+		Expr:    "src",
+		DstType: "model.Payment",
+		Cases: []model.TypeSwitchCase{
+			{Type: "*domain.Card", RHS: "fromCard(v)", Ptr: true},
+			{Type: "domain.Bank", RHS: "fromBank(v)", Error: true},
+			{Type: "domain.Cash", RHS: "fromCash(v)", RetType: "*model.Cash"},
+			{Type: "domain.Coupon", RHS: "fromCoupon(v)", Error: true, RetType: "*model.Coupon"},
+		},
+	}
+
+	t.Run("String", func(t *testing.T) {
+		expected := `switch v := src.(type) {
+case nil:
+case *domain.Card:
+if v != nil {
+dst = fromCard(v)
+}
+case domain.Bank:
+dst, err = fromBank(v)
+case domain.Cash:
+r := fromCash(v)
+if r != nil {
+dst = r
+}
+case domain.Coupon:
+var r *model.Coupon
+r, err = fromCoupon(v)
+if r != nil {
+dst = r
+}
+default:
+err = fmt.Errorf("cannot convert %T into model.Payment", src)
+}
+`
+		actual := tsf.String()
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("RetError", func(t *testing.T) {
+		actual := tsf.RetError()
+		require.False(t, actual)
+	})
+}
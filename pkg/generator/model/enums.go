@@ -60,3 +60,33 @@ func NewMatchRuleFromValue(v string) (MatchRule, bool) {
 	}
 	return "", false
 }
+
+// NilPolicy represents how to handle a nil pointer that is to be dereferenced.
+type NilPolicy string
+
+// String returns the string representation of the nil policy.
+func (s NilPolicy) String() string {
+	return string(s)
+}
+
+const (
+	// NilPolicyZero indicates that the destination takes its zero value.
+	NilPolicyZero = NilPolicy("zero")
+	// NilPolicySkip indicates that the destination is left untouched.
+	NilPolicySkip = NilPolicy("skip")
+	// NilPolicyError indicates that the function returns an error.
+	NilPolicyError = NilPolicy("error")
+)
+
+// NilPolicyValues is a slice of all possible nil policies.
+var NilPolicyValues = []NilPolicy{NilPolicyZero, NilPolicySkip, NilPolicyError}
+
+// NewNilPolicyFromValue creates a new NilPolicy instance from the given value string.
+func NewNilPolicyFromValue(v string) (NilPolicy, bool) {
+	for _, policy := range NilPolicyValues {
+		if policy.String() == v {
+			return policy, true
+		}
+	}
+	return "", false
+}
@@ -68,3 +68,31 @@ func TestMatchRuleValues(t *testing.T) {
 		assert.Equal(t, model.MatchRule(""), rule)
 	})
 }
+
+func TestNilPolicyValues(t *testing.T) {
+	t.Run("NilPolicyValues", func(t *testing.T) {
+		assert.ElementsMatch(t, []model.NilPolicy{
+			model.NilPolicyZero,
+			model.NilPolicySkip,
+			model.NilPolicyError,
+		}, model.NilPolicyValues)
+	})
+
+	t.Run("NewNilPolicyFromValue", func(t *testing.T) {
+		policy, ok := model.NewNilPolicyFromValue("zero")
+		assert.True(t, ok)
+		assert.Equal(t, model.NilPolicyZero, policy)
+
+		policy, ok = model.NewNilPolicyFromValue("skip")
+		assert.True(t, ok)
+		assert.Equal(t, model.NilPolicySkip, policy)
+
+		policy, ok = model.NewNilPolicyFromValue("error")
+		assert.True(t, ok)
+		assert.Equal(t, model.NilPolicyError, policy)
+
+		policy, ok = model.NewNilPolicyFromValue("invalid")
+		assert.False(t, ok)
+		assert.Equal(t, model.NilPolicy(""), policy)
+	})
+}
@@ -16,6 +16,7 @@ type Function struct {
 	AdditionalArgs []Var        // AdditionalArgs is the additional arguments variables.
 	RetError       bool         // RetError indicates whether the function returns an error.
 	DstVarStyle    DstVarStyle  // DstVarStyle is the style of the destination variable declaration.
+	NilCheck       bool         // NilCheck indicates whether the function returns immediately if the source is nil.
 	Assignments    []Assignment // Assignments is the list of assignments in the function body.
 	PreProcess     *Manipulator // PreProcess is the function that is applied before the assignments.
 	PostProcess    *Manipulator // PostProcess is the function that is applied after the assignments.
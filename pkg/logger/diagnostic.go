@@ -0,0 +1,81 @@
+package logger
+
+import (
+	"go/token"
+)
+
+// Severity represents the severity of a diagnostic.
+type Severity string
+
+const (
+	// SeverityError indicates that convergen failed to generate the code.
+	SeverityError Severity = "error"
+	// SeverityWarning indicates that convergen generated the code, but it may not be as expected.
+	SeverityWarning Severity = "warning"
+)
+
+// Code identifies the kind of a diagnostic. It is stable across releases so that tools can rely on it.
+type Code string
+
+const (
+	// CodeLoadFailed is for a package or a setup file that cannot be loaded.
+	CodeLoadFailed Code = "load-failed"
+	// CodeGenerateFailed is for the generated code that cannot be formatted or written.
+	CodeGenerateFailed Code = "generate-failed"
+	// CodeOutOfDate is for a generated file that differs from the code convergen generates now.
+	CodeOutOfDate Code = "out-of-date"
+	// CodeUnknownNotation is for a notation that convergen doesn't know.
+	CodeUnknownNotation Code = "unknown-notation"
+	// CodeMisplacedNotation is for a notation that is not available in the location.
+	CodeMisplacedNotation Code = "misplaced-notation"
+	// CodeMissingArgument is for a notation that lacks its arguments.
+	CodeMissingArgument Code = "missing-argument"
+	// CodeInvalidArgument is for a notation that has an invalid argument.
+	CodeInvalidArgument Code = "invalid-argument"
+	// CodeConflictingNotations is for notations that cannot be used together.
+	CodeConflictingNotations Code = "conflicting-notations"
+	// CodeNotFound is for an interface, a function, a type or a field that a notation refers to but doesn't exist.
+	CodeNotFound Code = "not-found"
+	// CodeInvalidMethod is for a method of a convergen interface that convergen cannot implement.
+	CodeInvalidMethod Code = "invalid-method"
+	// CodeInvalidFunction is for a function that a notation refers to but whose signature doesn't fit.
+	CodeInvalidFunction Code = "invalid-function"
+	// CodeAmbiguousMatch is for a field that matches more than one counterpart.
+	CodeAmbiguousMatch Code = "ambiguous-match"
+	// CodeNoAssignment is for a destination field that nothing is assigned to.
+	CodeNoAssignment Code = "no-assignment"
+	// CodeUnsupportedConversion is for a conversion that convergen cannot generate.
+	CodeUnsupportedConversion Code = "unsupported-conversion"
+	// CodeErrorReturnRequired is for a conversion that needs the method to return an error.
+	CodeErrorReturnRequired Code = "error-return-required"
+)
+
+// FixReturnError is the suggested fix for the diagnostics of CodeErrorReturnRequired.
+const FixReturnError = "make the method return an error as its last result"
+
+// Diagnostic represents an error or a warning that a Logger reports.
+type Diagnostic struct {
+	Pos      token.Position // Pos is the position in the setup file, or only the file name for a whole file.
+	Severity Severity       // Severity is the severity of the diagnostic.
+	Code     Code           // Code identifies the kind of the diagnostic.
+	Message  string         // Message describes the problem, without the position.
+	Fix      string         // Fix suggests how to fix the problem, if any.
+}
+
+// String returns the position and the message as "<pos>: <message>".
+func (d Diagnostic) String() string {
+	if !d.hasPos() {
+		return d.Message
+	}
+	return d.Pos.String() + ": " + d.Message
+}
+
+// Error implements the error interface.
+func (d Diagnostic) Error() string {
+	return d.String()
+}
+
+// hasPos returns true if the diagnostic refers to a file.
+func (d Diagnostic) hasPos() bool {
+	return d.Pos.Filename != "" || d.Pos.IsValid()
+}
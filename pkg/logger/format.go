@@ -0,0 +1,218 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// Format represents an output format of diagnostics.
+type Format string
+
+const (
+	// FormatText writes a line per diagnostic for humans, followed by the suggested fix if any.
+	FormatText Format = "text"
+	// FormatJSON writes a JSON array of the diagnostics.
+	FormatJSON Format = "json"
+	// FormatSARIF writes a SARIF 2.1.0 log for code scanning tools.
+	FormatSARIF Format = "sarif"
+)
+
+// FormatValues are the available output formats of diagnostics.
+var FormatValues = []Format{FormatText, FormatJSON, FormatSARIF}
+
+// ParseFormat returns the format of the name, or an error if the name is unknown.
+func ParseFormat(name string) (Format, error) {
+	for _, f := range FormatValues {
+		if string(f) == name {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unknown diagnostics format %q, it should be one of %v", name, FormatValues)
+}
+
+// WriteDiagnostics writes the diagnostics to w in the format.
+func WriteDiagnostics(w io.Writer, format Format, diagnostics []Diagnostic) error {
+	switch format {
+	case FormatJSON:
+		return writeJSON(w, diagnostics)
+	case FormatSARIF:
+		return writeSARIF(w, diagnostics)
+	default:
+		return writeText(w, diagnostics)
+	}
+}
+
+// writeText writes the diagnostics as "<pos>: <severity>: <message> (<code>)".
+func writeText(w io.Writer, diagnostics []Diagnostic) error {
+	var sb strings.Builder
+	for _, d := range diagnostics {
+		if d.hasPos() {
+			sb.WriteString(d.Pos.String())
+			sb.WriteString(": ")
+		}
+		sb.WriteString(string(d.Severity))
+		sb.WriteString(": ")
+		sb.WriteString(d.Message)
+		if d.Code != "" {
+			sb.WriteString(" (")
+			sb.WriteString(string(d.Code))
+			sb.WriteString(")")
+		}
+		sb.WriteString("\n")
+		if d.Fix != "" {
+			sb.WriteString("\tfix: ")
+			sb.WriteString(d.Fix)
+			sb.WriteString("\n")
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// jsonDiagnostic is the JSON representation of a diagnostic.
+type jsonDiagnostic struct {
+	File     string   `json:"file,omitempty"`
+	Line     int      `json:"line,omitempty"`
+	Column   int      `json:"column,omitempty"`
+	Severity Severity `json:"severity"`
+	Code     Code     `json:"code,omitempty"`
+	Message  string   `json:"message"`
+	Fix      string   `json:"fix,omitempty"`
+}
+
+// writeJSON writes the diagnostics as a JSON array.
+func writeJSON(w io.Writer, diagnostics []Diagnostic) error {
+	list := make([]jsonDiagnostic, 0, len(diagnostics))
+	for _, d := range diagnostics {
+		list = append(list, jsonDiagnostic{
+			File:     d.Pos.Filename,
+			Line:     d.Pos.Line,
+			Column:   d.Pos.Column,
+			Severity: d.Severity,
+			Code:     d.Code,
+			Message:  d.Message,
+			Fix:      d.Fix,
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(list)
+}
+
+// The types below are the subset of SARIF 2.1.0 that convergen writes.
+// See https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html for the specification.
+type (
+	sarifLog struct {
+		Schema  string     `json:"$schema"`
+		Version string     `json:"version"`
+		Runs    []sarifRun `json:"runs"`
+	}
+	sarifRun struct {
+		Tool    sarifTool     `json:"tool"`
+		Results []sarifResult `json:"results"`
+	}
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+	sarifDriver struct {
+		Name           string      `json:"name"`
+		InformationURI string      `json:"informationUri"`
+		Rules          []sarifRule `json:"rules"`
+	}
+	sarifRule struct {
+		ID string `json:"id"`
+	}
+	sarifResult struct {
+		RuleID    string          `json:"ruleId,omitempty"`
+		Level     string          `json:"level"`
+		Message   sarifMessage    `json:"message"`
+		Locations []sarifLocation `json:"locations,omitempty"`
+	}
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+	sarifLocation struct {
+		PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	}
+	sarifPhysicalLocation struct {
+		ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+		Region           *sarifRegion          `json:"region,omitempty"`
+	}
+	sarifArtifactLocation struct {
+		URI string `json:"uri"`
+	}
+	sarifRegion struct {
+		StartLine   int `json:"startLine"`
+		StartColumn int `json:"startColumn,omitempty"`
+	}
+)
+
+// writeSARIF writes the diagnostics as a SARIF log with a run of convergen.
+// The suggested fix is appended to the message, as SARIF fixes need the exact replacements.
+func writeSARIF(w io.Writer, diagnostics []Diagnostic) error {
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           "convergen",
+				InformationURI: "https://github.com/reedom/convergen",
+				Rules:          []sarifRule{},
+			},
+		},
+		Results: []sarifResult{},
+	}
+
+	rules := make(map[Code]bool)
+	for _, d := range diagnostics {
+		if d.Code != "" && !rules[d.Code] {
+			rules[d.Code] = true
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: string(d.Code)})
+		}
+
+		result := sarifResult{
+			RuleID:  string(d.Code),
+			Level:   string(d.Severity),
+			Message: sarifMessage{Text: d.Message},
+		}
+		if d.Fix != "" {
+			result.Message.Text += "\nFix: " + d.Fix
+		}
+		if d.Pos.Filename != "" {
+			loc := sarifLocation{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: fileURI(d.Pos.Filename)},
+				},
+			}
+			if 0 < d.Pos.Line {
+				loc.PhysicalLocation.Region = &sarifRegion{StartLine: d.Pos.Line, StartColumn: d.Pos.Column}
+			}
+			result.Locations = []sarifLocation{loc}
+		}
+		run.Results = append(run.Results, result)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	})
+}
+
+// fileURI returns the URI of the file path; a "file" URL for an absolute path,
+// or a relative reference otherwise.
+func fileURI(filePath string) string {
+	u := url.URL{Path: filepath.ToSlash(filePath)}
+	if filepath.IsAbs(filePath) {
+		u.Scheme = "file"
+		if !strings.HasPrefix(u.Path, "/") {
+			// A Windows path such as "C:/dir/file.go".
+			u.Path = "/" + u.Path
+		}
+	}
+	return u.String()
+}
@@ -0,0 +1,149 @@
+package logger_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/token"
+	"testing"
+
+	"github.com/reedom/convergen/v8/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDiagnostics() []logger.Diagnostic {
+	log := logger.New()
+	pos := token.Position{Filename: "/src/setup.go", Line: 12, Column: 2}
+	_ = log.ErrorAt(pos, logger.CodeNotFound, "function %v not found", "toModel")
+	_ = log.Report(logger.Diagnostic{
+		Pos:      pos,
+		Severity: logger.SeverityWarning,
+		Code:     logger.CodeNoAssignment,
+		Message:  "no assignment for dst.ID [int]",
+		Fix:      `add ":skip ID" to the method to leave it unassigned`,
+	})
+	log.WarnAt(token.Position{}, logger.CodeNotFound, "./...: no convergen setup files found")
+	return log.Diagnostics()
+}
+
+func TestLogger_ErrorAt(t *testing.T) {
+	t.Parallel()
+
+	log := logger.New()
+	err := log.ErrorAt(token.Position{Filename: "setup.go", Line: 3, Column: 1}, logger.CodeNotFound, "type %v not found", "T")
+	assert.EqualError(t, err, "setup.go:3:1: type T not found")
+	assert.True(t, log.HasErrors())
+
+	diagnostics := log.Diagnostics()
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, "type T not found", diagnostics[0].Message)
+	assert.Equal(t, 3, diagnostics[0].Pos.Line)
+}
+
+func TestWriteDiagnostics_Text(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.Nil(t, logger.WriteDiagnostics(&buf, logger.FormatText, testDiagnostics()))
+	assert.Equal(t, `/src/setup.go:12:2: error: function toModel not found (not-found)
+/src/setup.go:12:2: warning: no assignment for dst.ID [int] (no-assignment)
+	fix: add ":skip ID" to the method to leave it unassigned
+warning: ./...: no convergen setup files found (not-found)
+`, buf.String())
+}
+
+func TestWriteDiagnostics_JSON(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.Nil(t, logger.WriteDiagnostics(&buf, logger.FormatJSON, testDiagnostics()))
+
+	var actual []map[string]any
+	require.Nil(t, json.Unmarshal(buf.Bytes(), &actual))
+	require.Len(t, actual, 3)
+	assert.Equal(t, map[string]any{
+		"file":     "/src/setup.go",
+		"line":     float64(12),
+		"column":   float64(2),
+		"severity": "warning",
+		"code":     "no-assignment",
+		"message":  "no assignment for dst.ID [int]",
+		"fix":      `add ":skip ID" to the method to leave it unassigned`,
+	}, actual[1])
+	assert.NotContains(t, actual[2], "file")
+
+	buf.Reset()
+	require.Nil(t, logger.WriteDiagnostics(&buf, logger.FormatJSON, nil))
+	assert.Equal(t, "[]\n", buf.String())
+}
+
+func TestWriteDiagnostics_SARIF(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.Nil(t, logger.WriteDiagnostics(&buf, logger.FormatSARIF, testDiagnostics()))
+
+	var actual struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Name  string `json:"name"`
+					Rules []struct {
+						ID string `json:"id"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID  string `json:"ruleId"`
+				Level   string `json:"level"`
+				Message struct {
+					Text string `json:"text"`
+				} `json:"message"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+						Region struct {
+							StartLine   int `json:"startLine"`
+							StartColumn int `json:"startColumn"`
+						} `json:"region"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	require.Nil(t, json.Unmarshal(buf.Bytes(), &actual))
+	assert.Equal(t, "2.1.0", actual.Version)
+	require.Len(t, actual.Runs, 1)
+
+	run := actual.Runs[0]
+	assert.Equal(t, "convergen", run.Tool.Driver.Name)
+	require.Len(t, run.Tool.Driver.Rules, 2)
+	assert.Equal(t, "not-found", run.Tool.Driver.Rules[0].ID)
+	assert.Equal(t, "no-assignment", run.Tool.Driver.Rules[1].ID)
+
+	require.Len(t, run.Results, 3)
+	assert.Equal(t, "error", run.Results[0].Level)
+	assert.Equal(t, "not-found", run.Results[0].RuleID)
+	require.Len(t, run.Results[0].Locations, 1)
+	loc := run.Results[0].Locations[0].PhysicalLocation
+	assert.Equal(t, "file:///src/setup.go", loc.ArtifactLocation.URI)
+	assert.Equal(t, 12, loc.Region.StartLine)
+	assert.Equal(t, 2, loc.Region.StartColumn)
+	assert.Equal(t, "no assignment for dst.ID [int]\nFix: add \":skip ID\" to the method to leave it unassigned",
+		run.Results[1].Message.Text)
+	assert.Empty(t, run.Results[2].Locations)
+}
+
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+
+	format, err := logger.ParseFormat("sarif")
+	assert.Nil(t, err)
+	assert.Equal(t, logger.FormatSARIF, format)
+
+	_, err = logger.ParseFormat("xml")
+	assert.NotNil(t, err)
+}
@@ -2,9 +2,10 @@ package logger
 
 import (
 	"fmt"
+	"go/token"
 	"io"
 	"log"
-	"os"
+	"sync"
 )
 
 // LoggerOpt is a function that modifies the logger options.
@@ -12,77 +13,105 @@ type LoggerOpt func(*option)
 
 // option is a structure that holds the logger options.
 type option struct {
-	enabled bool      // enabled is a flag that determines whether the logger is enabled or not.
-	forTest bool      // forTest is a flag that determines whether the logger is used for testing or not.
-	out     io.Writer // out is the output destination of the logger.
+	out io.Writer // out is the output destination of the log messages.
 }
 
-var logger = log.New(io.Discard, "", 0) // logger is the info logger instance.
-var elogger = log.New(os.Stderr, "", 0) // elogger is the error logger instance.
-
-// Enable sets the enabled flag to true.
-func Enable() LoggerOpt {
-	return func(opt *option) {
-		opt.enabled = true
-	}
+// Logger writes log messages and collects the diagnostics of a convergen run.
+// A Logger is safe for concurrent use, while each convergen run should have its own one.
+type Logger struct {
+	mu          sync.Mutex
+	logger      *log.Logger  // logger is the info logger.
+	diagnostics []Diagnostic // diagnostics are the errors and warnings reported so far.
 }
 
-// Output sets the output destination of the logger.
+// Output sets the output destination of the log messages.
 func Output(out io.Writer) LoggerOpt {
 	return func(opt *option) {
 		opt.out = out
 	}
 }
 
-// ForTest sets the forTest flag to true.
-func ForTest() LoggerOpt {
-	return func(opt *option) {
-		opt.forTest = true
+// New returns a new logger with the provided options.
+// By default, the logger writes nothing and only collects the diagnostics,
+// which the caller writes in the format of its choice.
+func New(options ...LoggerOpt) *Logger {
+	opt := option{
+		out: io.Discard,
 	}
-}
-
-// SetupLogger sets up the logger with the provided options.
-func SetupLogger(options ...LoggerOpt) {
-	opt := option{}
 	for _, o := range options {
 		o(&opt)
 	}
 
-	if !opt.enabled {
-		logger = log.New(io.Discard, "", 0)
-		elogger = log.New(os.Stderr, "", 0)
-	} else if opt.out != nil {
-		//f, err := os.OpenFile(opt.outputPath, os.O_WRONLY | os.O_CREATE | os.O_TRUNC, 0644)
-		//if err != nil {
-		//	return err
-		//}
-		logger = log.New(opt.out, "", log.LstdFlags)
-		elogger = log.New(os.Stderr, "", 0)
-	} else {
-		logger = log.New(os.Stdout, "", log.LstdFlags)
-		elogger = log.New(io.Discard, "", 0)
-	}
-
-	if opt.forTest {
-		elogger = log.New(io.Discard, "", 0)
+	return &Logger{
+		logger: log.New(opt.out, "", log.LstdFlags),
 	}
 }
 
-// Errorf logs the formatted error message.
-func Errorf(format string, a ...any) error {
+// ErrorAt reports the formatted error message at pos with the code, and returns it as an error
+// that reads "<pos>: <message>". The format may wrap an error with the %w verb.
+func (l *Logger) ErrorAt(pos token.Position, code Code, format string, a ...any) error {
 	err := fmt.Errorf(format, a...)
-	logger.Println(err.Error())
-	elogger.Println(err.Error())
+	d := Diagnostic{
+		Pos:      pos,
+		Severity: SeverityError,
+		Code:     code,
+		Message:  err.Error(),
+	}
+	l.report(d)
+	if d.hasPos() {
+		return fmt.Errorf("%v: %w", pos, err)
+	}
 	return err
 }
 
-// Warnf logs the formatted warning message.
-func Warnf(format string, a ...any) {
-	logger.Printf(format, a...)
-	elogger.Printf(format, a...)
+// WarnAt reports the formatted warning message at pos with the code.
+func (l *Logger) WarnAt(pos token.Position, code Code, format string, a ...any) {
+	l.report(Diagnostic{
+		Pos:      pos,
+		Severity: SeverityWarning,
+		Code:     code,
+		Message:  fmt.Sprintf(format, a...),
+	})
+}
+
+// Report reports the diagnostic as it is, which is useful to suggest a fix.
+// It returns the diagnostic as an error if it is an error, or nil.
+func (l *Logger) Report(d Diagnostic) error {
+	l.report(d)
+	if d.Severity == SeverityError {
+		return d
+	}
+	return nil
 }
 
 // Printf logs the formatted message.
-func Printf(format string, a ...any) {
-	logger.Printf(format, a...)
+func (l *Logger) Printf(format string, a ...any) {
+	l.logger.Printf(format, a...)
+}
+
+// Diagnostics returns the errors and warnings reported so far.
+func (l *Logger) Diagnostics() []Diagnostic {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]Diagnostic(nil), l.diagnostics...)
+}
+
+// HasErrors returns true if any error has been reported.
+func (l *Logger) HasErrors() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, d := range l.diagnostics {
+		if d.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// report logs the diagnostic and records it.
+func (l *Logger) report(d Diagnostic) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.diagnostics = append(l.diagnostics, d)
+	l.logger.Println(d.String())
 }
@@ -0,0 +1,156 @@
+package option
+
+import (
+	"go/types"
+	"sort"
+)
+
+// StdconvPkgPath is the import path of the package that provides the built-in converters
+// which neither the standard library nor a type conversion covers.
+const StdconvPkgPath = "github.com/reedom/convergen/v8/pkg/stdconv"
+
+// BuiltinConverter represents one of the well-known conversions that the ":builtin" notation enables.
+type BuiltinConverter struct {
+	group    string                // The name of the group the converter belongs to.
+	pkgPath  string                // The import path of the function, or "" for a type conversion.
+	name     string                // The name of the function or the type to convert into.
+	src      func(types.Type) bool // Reports whether the converter accepts the type as its argument.
+	dst      func(types.Type) bool // Reports whether the converter's return value is assignable to the type.
+	retError bool                  // Indicates whether the converter returns an error.
+}
+
+// builtinConverters is the catalog of the built-in converters.
+var builtinConverters = []*BuiltinConverter{
+	{group: "time", pkgPath: StdconvPkgPath, name: "UnixMilli", src: isNamedOf("time", "Time"), dst: isBasic(types.Int64)},
+	{group: "time", pkgPath: "time", name: "UnixMilli", src: isBasic(types.Int64), dst: isNamedOf("time", "Time")},
+	{group: "time", name: "int64", src: isNamedOf("time", "Duration"), dst: isBasic(types.Int64)},
+	{group: "time", pkgPath: "time", name: "Duration", src: isBasic(types.Int64), dst: isNamedOf("time", "Duration")},
+
+	{group: "strconv", pkgPath: "strconv", name: "Itoa", src: isBasic(types.Int), dst: isBasic(types.String)},
+	{group: "strconv", pkgPath: "strconv", name: "Atoi", src: isBasic(types.String), dst: isBasic(types.Int), retError: true},
+	{group: "strconv", pkgPath: StdconvPkgPath, name: "FormatInt64", src: isBasic(types.Int64), dst: isBasic(types.String)},
+	{group: "strconv", pkgPath: StdconvPkgPath, name: "ParseInt64", src: isBasic(types.String), dst: isBasic(types.Int64), retError: true},
+	{group: "strconv", pkgPath: StdconvPkgPath, name: "FormatFloat64", src: isBasic(types.Float64), dst: isBasic(types.String)},
+	{group: "strconv", pkgPath: StdconvPkgPath, name: "ParseFloat64", src: isBasic(types.String), dst: isBasic(types.Float64), retError: true},
+	{group: "strconv", pkgPath: "strconv", name: "FormatBool", src: isBasic(types.Bool), dst: isBasic(types.String)},
+	{group: "strconv", pkgPath: "strconv", name: "ParseBool", src: isBasic(types.String), dst: isBasic(types.Bool), retError: true},
+
+	{group: "bytes", name: "string", src: isBytes, dst: isBasic(types.String)},
+	{group: "bytes", name: "[]byte", src: isBasic(types.String), dst: isBytes},
+
+	{group: "uuid", pkgPath: StdconvPkgPath, name: "FormatUUID", src: isUUID, dst: isBasic(types.String)},
+	{group: "uuid", pkgPath: StdconvPkgPath, name: "ParseUUID", src: isBasic(types.String), dst: isUUID, retError: true},
+}
+
+// BuiltinGroups returns the names of the built-in converter groups in alphabetical order.
+func BuiltinGroups() []string {
+	var groups []string
+	for _, converter := range builtinConverters {
+		found := false
+		for _, group := range groups {
+			found = found || group == converter.group
+		}
+		if !found {
+			groups = append(groups, converter.group)
+		}
+	}
+	sort.Strings(groups)
+	return groups
+}
+
+// ValidBuiltinGroup returns true if group is the name of a built-in converter group.
+func ValidBuiltinGroup(group string) bool {
+	for _, converter := range builtinConverters {
+		if converter.group == group {
+			return true
+		}
+	}
+	return false
+}
+
+// BuiltinPkgPaths returns the import paths of the functions in the group.
+func BuiltinPkgPaths(group string) []string {
+	var paths []string
+	for _, converter := range builtinConverters {
+		if converter.group != group || converter.pkgPath == "" {
+			continue
+		}
+		found := false
+		for _, p := range paths {
+			found = found || p == converter.pkgPath
+		}
+		if !found {
+			paths = append(paths, converter.pkgPath)
+		}
+	}
+	return paths
+}
+
+// lookupBuiltinConverter returns the first built-in converter in the groups that converts src into dst, or nil.
+func lookupBuiltinConverter(groups []string, src, dst types.Type) *BuiltinConverter {
+	for _, converter := range builtinConverters {
+		for _, group := range groups {
+			if converter.group == group && converter.Match(src, dst) {
+				return converter
+			}
+		}
+	}
+	return nil
+}
+
+// Group returns the name of the group the converter belongs to.
+func (c *BuiltinConverter) Group() string {
+	return c.group
+}
+
+// PkgPath returns the import path of the function, or "" if the converter is a type conversion
+// or a universe function.
+func (c *BuiltinConverter) PkgPath() string {
+	return c.pkgPath
+}
+
+// Name returns the name of the function, or the name of the type to convert into.
+func (c *BuiltinConverter) Name() string {
+	return c.name
+}
+
+// RetError returns true if the converter returns an error.
+func (c *BuiltinConverter) RetError() bool {
+	return c.retError
+}
+
+// Match returns true if the converter converts src into dst.
+func (c *BuiltinConverter) Match(src, dst types.Type) bool {
+	return c.src(src) && c.dst(dst)
+}
+
+// isBasic returns a matcher for the basic type of the kind, excluding named types of it.
+func isBasic(kind types.BasicKind) func(types.Type) bool {
+	return func(t types.Type) bool {
+		return types.Identical(types.Unalias(t), types.Typ[kind])
+	}
+}
+
+// isNamedOf returns a matcher for the named type in the package.
+func isNamedOf(pkgPath, name string) func(types.Type) bool {
+	return func(t types.Type) bool {
+		named, ok := types.Unalias(t).(*types.Named)
+		if !ok {
+			return false
+		}
+		obj := named.Obj()
+		return obj.Pkg() != nil && obj.Pkg().Path() == pkgPath && obj.Name() == name
+	}
+}
+
+// isBytes matches []byte and the named types of it, such as json.RawMessage.
+func isBytes(t types.Type) bool {
+	slice, ok := t.Underlying().(*types.Slice)
+	return ok && types.Identical(slice.Elem(), types.Typ[types.Byte])
+}
+
+// isUUID matches [16]byte and the named types of it, such as uuid.UUID.
+func isUUID(t types.Type) bool {
+	array, ok := t.Underlying().(*types.Array)
+	return ok && array.Len() == 16 && types.Identical(array.Elem(), types.Typ[types.Byte])
+}
@@ -0,0 +1,61 @@
+package option_test
+
+import (
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/reedom/convergen/v8/pkg/option"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuiltinGroups(t *testing.T) {
+	assert.Equal(t, []string{"bytes", "strconv", "time", "uuid"}, option.BuiltinGroups())
+	assert.True(t, option.ValidBuiltinGroup("time"))
+	assert.False(t, option.ValidBuiltinGroup("json"))
+
+	assert.Equal(t, []string{option.StdconvPkgPath, "time"}, option.BuiltinPkgPaths("time"))
+	assert.Empty(t, option.BuiltinPkgPaths("bytes"))
+}
+
+func TestLookupBuiltinConverter(t *testing.T) {
+	timePkg := types.NewPackage("time", "time")
+	timeType := types.NewNamed(types.NewTypeName(token.NoPos, timePkg, "Time", nil), types.NewStruct(nil, nil), nil)
+	uuidType := types.NewNamed(types.NewTypeName(token.NoPos, nil, "UUID", nil), types.NewArray(types.Typ[types.Byte], 16), nil)
+	myInt := types.NewNamed(types.NewTypeName(token.NoPos, nil, "MyInt", nil), types.Typ[types.Int], nil)
+	bytesType := types.NewSlice(types.Typ[types.Byte])
+
+	opts := option.NewOptions()
+	assert.Nil(t, opts.LookupBuiltinConverter(types.Typ[types.Int], types.Typ[types.String]), "no groups")
+
+	opts.Builtins = []string{"time", "strconv", "bytes", "uuid"}
+	cases := []struct {
+		src, dst types.Type
+		pkgPath  string
+		name     string
+		retError bool
+	}{
+		{src: timeType, dst: types.Typ[types.Int64], pkgPath: option.StdconvPkgPath, name: "UnixMilli"},
+		{src: types.Typ[types.Int64], dst: timeType, pkgPath: "time", name: "UnixMilli"},
+		{src: types.Typ[types.Int], dst: types.Typ[types.String], pkgPath: "strconv", name: "Itoa"},
+		{src: types.Typ[types.String], dst: types.Typ[types.Int], pkgPath: "strconv", name: "Atoi", retError: true},
+		{src: bytesType, dst: types.Typ[types.String], name: "string"},
+		{src: types.Typ[types.String], dst: bytesType, name: "[]byte"},
+		{src: uuidType, dst: types.Typ[types.String], pkgPath: option.StdconvPkgPath, name: "FormatUUID"},
+		{src: types.Typ[types.String], dst: uuidType, pkgPath: option.StdconvPkgPath, name: "ParseUUID", retError: true},
+	}
+	for _, tt := range cases {
+		converter := opts.LookupBuiltinConverter(tt.src, tt.dst)
+		require.NotNil(t, converter, tt.name)
+		assert.Equal(t, tt.pkgPath, converter.PkgPath(), tt.name)
+		assert.Equal(t, tt.name, converter.Name())
+		assert.Equal(t, tt.retError, converter.RetError(), tt.name)
+	}
+
+	assert.Nil(t, opts.LookupBuiltinConverter(myInt, types.Typ[types.String]), "named basic type")
+	assert.Nil(t, opts.LookupBuiltinConverter(types.Typ[types.Int32], types.Typ[types.String]))
+
+	opts.Builtins = []string{"bytes"}
+	assert.Nil(t, opts.LookupBuiltinConverter(types.Typ[types.Int], types.Typ[types.String]), "disabled group")
+}
@@ -0,0 +1,17 @@
+package option
+
+import (
+	"go/types"
+)
+
+// Converter represents a converter function that takes a single argument.
+type Converter interface {
+	// Converter returns the name of the converter function.
+	Converter() string
+	// ArgType returns the type of the converter's argument.
+	ArgType() types.Type
+	// RetType returns the type of the converter's return value.
+	RetType() types.Type
+	// RetError returns true if the converter returns an error.
+	RetError() bool
+}
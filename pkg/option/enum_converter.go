@@ -0,0 +1,168 @@
+package option
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// EnumConverter represents a conversion between two named types that have constant sets.
+// The constants are paired by their names, with the parts outside of "*" in the patterns stripped.
+type EnumConverter struct {
+	src        string    // The type expression of the source.
+	dst        string    // The type expression of the destination.
+	srcPattern string    // The name pattern of the source constants, e.g. "Status*".
+	dstPattern string    // The name pattern of the destination constants.
+	pos        token.Pos // The position of the converter in the source code.
+
+	srcType types.Type // The source type.
+	dstType types.Type // The destination type.
+	pairs   []EnumPair // The pairs of the constants that have distinct source values.
+}
+
+// EnumPair represents a pair of a source constant and its destination constant.
+type EnumPair struct {
+	Src *types.Const
+	Dst *types.Const
+}
+
+// NewEnumConverter creates a new EnumConverter with the given parameters.
+// An empty pattern means "*", i.e. the constant name as it is.
+func NewEnumConverter(src, dst, srcPattern, dstPattern string, pos token.Pos) *EnumConverter {
+	if srcPattern == "" {
+		srcPattern = "*"
+	}
+	if dstPattern == "" {
+		dstPattern = "*"
+	}
+	return &EnumConverter{
+		src:        src,
+		dst:        dst,
+		srcPattern: srcPattern,
+		dstPattern: dstPattern,
+		pos:        pos,
+	}
+}
+
+// ValidEnumPattern returns true if the pattern has exactly one "*".
+func ValidEnumPattern(pattern string) bool {
+	return strings.Count(pattern, "*") == 1
+}
+
+// SetTypes sets the source and destination types that the EnumConverter applies to.
+func (c *EnumConverter) SetTypes(srcType, dstType types.Type) {
+	c.srcType = srcType
+	c.dstType = dstType
+}
+
+// Resolved returns true if the types of the EnumConverter have been set.
+func (c *EnumConverter) Resolved() bool {
+	return c.srcType != nil && c.dstType != nil
+}
+
+// Match returns true if the EnumConverter converts src into a value that is assignable to dst.
+func (c *EnumConverter) Match(src, dst types.Type) bool {
+	if !c.Resolved() {
+		return false
+	}
+	return types.Identical(src, c.srcType) && types.AssignableTo(c.dstType, dst)
+}
+
+// Pair pairs the source constants with the destination constants by their names.
+// A constant that has the same value as another one on its side, i.e. an alias,
+// is regarded as paired if the other one is.
+// It returns an error if any constant on either side is left unpaired, or
+// a source value is paired with different destination values.
+func (c *EnumConverter) Pair(srcConsts, dstConsts []*types.Const, exactCase bool) error {
+	dstByKey := make(map[string]*types.Const)
+	for _, dst := range dstConsts {
+		key := enumKey(c.dstPattern, dst.Name(), exactCase)
+		if prev, ok := dstByKey[key]; ok {
+			return fmt.Errorf("%v and %v have the same name %v", prev.Name(), dst.Name(), key)
+		}
+		dstByKey[key] = dst
+	}
+
+	var pairs []EnumPair
+	pairedSrc := make(map[string]*types.Const)
+	pairedDst := make(map[string]bool)
+	for _, src := range srcConsts {
+		dst, ok := dstByKey[enumKey(c.srcPattern, src.Name(), exactCase)]
+		if !ok {
+			continue
+		}
+		srcValue := src.Val().ExactString()
+		if prev, ok := pairedSrc[srcValue]; ok {
+			if prev.Val().ExactString() != dst.Val().ExactString() {
+				return fmt.Errorf("%v maps to both %v and %v", src.Name(), prev.Name(), dst.Name())
+			}
+			continue
+		}
+		pairedSrc[srcValue] = dst
+		pairedDst[dst.Val().ExactString()] = true
+		pairs = append(pairs, EnumPair{Src: src, Dst: dst})
+	}
+
+	var unpaired []string
+	for _, src := range srcConsts {
+		if _, ok := pairedSrc[src.Val().ExactString()]; !ok {
+			unpaired = append(unpaired, src.Name())
+		}
+	}
+	for _, dst := range dstConsts {
+		if !pairedDst[dst.Val().ExactString()] {
+			unpaired = append(unpaired, dst.Name())
+		}
+	}
+	if 0 < len(unpaired) {
+		return fmt.Errorf("no pair for %v", strings.Join(unpaired, ", "))
+	}
+
+	c.pairs = pairs
+	return nil
+}
+
+// enumKey returns the part of the name that matches "*" in the pattern.
+// It returns the name as it is if the name does not match the pattern.
+func enumKey(pattern, name string, exactCase bool) string {
+	prefix, suffix, _ := strings.Cut(pattern, "*")
+	if len(prefix)+len(suffix) < len(name) &&
+		strings.HasPrefix(name, prefix) && strings.HasSuffix(name, suffix) {
+		name = name[len(prefix) : len(name)-len(suffix)]
+	}
+	if !exactCase {
+		name = strings.ToLower(name)
+	}
+	return name
+}
+
+// Src returns the type expression of the source.
+func (c *EnumConverter) Src() string {
+	return c.src
+}
+
+// Dst returns the type expression of the destination.
+func (c *EnumConverter) Dst() string {
+	return c.dst
+}
+
+// Pos returns the position of the EnumConverter.
+func (c *EnumConverter) Pos() token.Pos {
+	return c.pos
+}
+
+// SrcType returns the source type.
+func (c *EnumConverter) SrcType() types.Type {
+	return c.srcType
+}
+
+// DstType returns the destination type.
+func (c *EnumConverter) DstType() types.Type {
+	return c.dstType
+}
+
+// Pairs returns the pairs of the constants.
+func (c *EnumConverter) Pairs() []EnumPair {
+	return c.pairs
+}
@@ -0,0 +1,144 @@
+package option_test
+
+import (
+	"go/constant"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/reedom/convergen/v8/pkg/option"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newEnumConsts(typ types.Type, names ...string) []*types.Const {
+	consts := make([]*types.Const, len(names))
+	for i, name := range names {
+		consts[i] = types.NewConst(token.NoPos, nil, name, typ, constant.MakeInt64(int64(i)))
+	}
+	return consts
+}
+
+func TestEnumConverter(t *testing.T) {
+	srcType := types.NewNamed(types.NewTypeName(token.NoPos, nil, "Status", nil), types.Typ[types.Int], nil)
+	dstType := types.NewNamed(types.NewTypeName(token.NoPos, nil, "State", nil), types.Typ[types.Int], nil)
+
+	ec := option.NewEnumConverter("domain.Status", "model.State", "Status*", "", token.NoPos)
+	assert.Equal(t, "domain.Status", ec.Src())
+	assert.Equal(t, "model.State", ec.Dst())
+	assert.Equal(t, token.NoPos, ec.Pos())
+	assert.False(t, ec.Resolved())
+	assert.False(t, ec.Match(srcType, dstType))
+
+	ec.SetTypes(srcType, dstType)
+	assert.True(t, ec.Resolved())
+	assert.Equal(t, srcType, ec.SrcType())
+	assert.Equal(t, dstType, ec.DstType())
+	assert.True(t, ec.Match(srcType, dstType))
+	assert.False(t, ec.Match(dstType, srcType))
+
+	srcConsts := newEnumConsts(srcType, "StatusActive", "StatusInactive")
+	dstConsts := newEnumConsts(dstType, "Inactive", "Active")
+	require.Nil(t, ec.Pair(srcConsts, dstConsts, true))
+	pairs := ec.Pairs()
+	require.Len(t, pairs, 2)
+	assert.Equal(t, "StatusActive", pairs[0].Src.Name())
+	assert.Equal(t, "Active", pairs[0].Dst.Name())
+	assert.Equal(t, "StatusInactive", pairs[1].Src.Name())
+	assert.Equal(t, "Inactive", pairs[1].Dst.Name())
+}
+
+func TestEnumConverter_Pair(t *testing.T) {
+	srcType := types.NewNamed(types.NewTypeName(token.NoPos, nil, "Status", nil), types.Typ[types.Int], nil)
+	dstType := types.NewNamed(types.NewTypeName(token.NoPos, nil, "State", nil), types.Typ[types.Int], nil)
+
+	cases := []struct {
+		name       string
+		srcPattern string
+		dstPattern string
+		exactCase  bool
+		src        []*types.Const
+		dst        []*types.Const
+		pairs      int
+		err        string
+	}{
+		{
+			name:      "exact names",
+			exactCase: true,
+			src:       newEnumConsts(srcType, "Active", "Inactive"),
+			dst:       newEnumConsts(dstType, "Inactive", "Active"),
+			pairs:     2,
+		},
+		{
+			name:       "suffix",
+			srcPattern: "*Status",
+			dstPattern: "State*",
+			exactCase:  true,
+			src:        newEnumConsts(srcType, "ActiveStatus", "InactiveStatus"),
+			dst:        newEnumConsts(dstType, "StateActive", "StateInactive"),
+			pairs:      2,
+		},
+		{
+			name:      "case insensitive",
+			exactCase: false,
+			src:       newEnumConsts(srcType, "ACTIVE", "INACTIVE"),
+			dst:       newEnumConsts(dstType, "Active", "Inactive"),
+			pairs:     2,
+		},
+		{
+			name:      "unpaired",
+			exactCase: true,
+			src:       newEnumConsts(srcType, "Active", "Inactive", "Deleted"),
+			dst:       newEnumConsts(dstType, "Active", "Inactive", "Banned"),
+			err:       "no pair for Deleted, Banned",
+		},
+		{
+			name:      "alias",
+			exactCase: true,
+			src: append(newEnumConsts(srcType, "Active", "Inactive"),
+				types.NewConst(token.NoPos, nil, "Default", srcType, constant.MakeInt64(0))),
+			dst: append(newEnumConsts(dstType, "Active", "Inactive"),
+				types.NewConst(token.NoPos, nil, "Default", dstType, constant.MakeInt64(0))),
+			pairs: 2,
+		},
+		{
+			name:      "ambiguous alias",
+			exactCase: true,
+			src: append(newEnumConsts(srcType, "Active", "Inactive"),
+				types.NewConst(token.NoPos, nil, "Default", srcType, constant.MakeInt64(0))),
+			dst: append(newEnumConsts(dstType, "Active", "Inactive"),
+				types.NewConst(token.NoPos, nil, "Default", dstType, constant.MakeInt64(1))),
+			err: "Default maps to both Active and Default",
+		},
+		{
+			name:       "duplicated names",
+			dstPattern: "State*",
+			exactCase:  true,
+			src:        newEnumConsts(srcType, "Active"),
+			dst:        newEnumConsts(dstType, "Active", "StateActive"),
+			err:        "Active and StateActive have the same name Active",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ec := option.NewEnumConverter("Status", "State", tt.srcPattern, tt.dstPattern, token.NoPos)
+			err := ec.Pair(tt.src, tt.dst, tt.exactCase)
+			if tt.err != "" {
+				require.NotNil(t, err)
+				assert.Equal(t, tt.err, err.Error())
+				return
+			}
+			require.Nil(t, err)
+			assert.Len(t, ec.Pairs(), tt.pairs)
+		})
+	}
+}
+
+func TestValidEnumPattern(t *testing.T) {
+	assert.True(t, option.ValidEnumPattern("*"))
+	assert.True(t, option.ValidEnumPattern("Status*"))
+	assert.True(t, option.ValidEnumPattern("*Status"))
+	assert.False(t, option.ValidEnumPattern("Status"))
+	assert.False(t, option.ValidEnumPattern("*Status*"))
+}
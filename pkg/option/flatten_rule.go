@@ -0,0 +1,43 @@
+package option
+
+import (
+	"go/token"
+)
+
+// FlattenRule matches the fields of a nested struct with the prefixed fields of its container's counterpart,
+// e.g. "Address.City" with "AddressCity".
+type FlattenRule struct {
+	nested *IdentMatcher // The matcher for the nested struct field.
+	prefix string        // The prefix of the flat field names.
+	pos    token.Pos     // The position of the rule in the file.
+}
+
+// NewFlattenRule creates a new FlattenRule instance.
+// If prefix is empty, the name of the nested struct field is used, e.g. "Address" for "Info.Address".
+func NewFlattenRule(nested, prefix string, pos token.Pos) *FlattenRule {
+	matcher := NewIdentMatcher(nested)
+	if prefix == "" {
+		prefix = matcher.NameAt(matcher.PathLen() - 1)
+	}
+	return &FlattenRule{nested: matcher, prefix: prefix, pos: pos}
+}
+
+// Nested returns the IdentMatcher for the nested struct field.
+func (r *FlattenRule) Nested() *IdentMatcher {
+	return r.nested
+}
+
+// Prefix returns the prefix of the flat field names.
+func (r *FlattenRule) Prefix() string {
+	return r.prefix
+}
+
+// FlatName returns the flat field name for the field name in the nested struct, e.g. "AddressCity" for "City".
+func (r *FlattenRule) FlatName(name string) string {
+	return r.prefix + name
+}
+
+// Pos returns the token.Pos of FlattenRule.
+func (r *FlattenRule) Pos() token.Pos {
+	return r.pos
+}
@@ -0,0 +1,26 @@
+package option_test
+
+import (
+	"go/token"
+	"testing"
+
+	"github.com/reedom/convergen/v8/pkg/option"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlattenRule(t *testing.T) {
+	t.Parallel()
+
+	rule := option.NewFlattenRule("Address", "", token.NoPos)
+	assert.True(t, rule.Nested().Match("Address", true))
+	assert.Equal(t, "Address", rule.Prefix())
+	assert.Equal(t, "AddressCity", rule.FlatName("City"))
+	assert.Equal(t, token.NoPos, rule.Pos())
+
+	rule = option.NewFlattenRule("Info.Address", "", token.NoPos)
+	assert.True(t, rule.Nested().Match("Info.Address", true))
+	assert.Equal(t, "Address", rule.Prefix())
+
+	rule = option.NewFlattenRule("Address", "Addr", token.NoPos)
+	assert.Equal(t, "AddrCity", rule.FlatName("City"))
+}
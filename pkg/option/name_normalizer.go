@@ -0,0 +1,90 @@
+package option
+
+import (
+	"strings"
+	"unicode"
+)
+
+// NameNormalizer normalizes a field name before it is compared with another.
+type NameNormalizer interface {
+	// Normalize returns the normalized name.
+	Normalize(name string) string
+}
+
+// NewNameNormalizer returns the normalizer for the notation argument, or false if it is unknown.
+// arg is either "acronym", "snake", or a strip pattern that has one "*", e.g. "Db*" or "*Col".
+func NewNameNormalizer(arg string) (NameNormalizer, bool) {
+	switch arg {
+	case "acronym":
+		return acronymNormalizer{}, true
+	case "snake":
+		return snakeNormalizer{}, true
+	}
+	if strings.Count(arg, "*") != 1 {
+		return nil, false
+	}
+	prefix, suffix, _ := strings.Cut(arg, "*")
+	if prefix == "" && suffix == "" {
+		return nil, false
+	}
+	return stripNormalizer{prefix: prefix, suffix: suffix}, true
+}
+
+// acronymNormalizer folds acronyms into title case.
+type acronymNormalizer struct{}
+
+// Normalize converts every run of upper case letters into title case,
+// e.g. "UserID" into "UserId" and "URLPath" into "UrlPath".
+// The last letter of a run is kept as it is if it starts the next word.
+func (acronymNormalizer) Normalize(name string) string {
+	runes := []rune(name)
+	normalized := make([]rune, len(runes))
+	for i, r := range runes {
+		normalized[i] = r
+		if i == 0 || !unicode.IsUpper(r) || !unicode.IsUpper(runes[i-1]) {
+			continue
+		}
+		if i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			continue
+		}
+		normalized[i] = unicode.ToLower(r)
+	}
+	return string(normalized)
+}
+
+// snakeNormalizer converts snake case into camel case.
+type snakeNormalizer struct{}
+
+// Normalize converts a snake case name into camel case, e.g. "pk_id" into "PkId".
+// A name without underscores is returned as it is.
+func (snakeNormalizer) Normalize(name string) string {
+	if !strings.Contains(name, "_") {
+		return name
+	}
+
+	var sb strings.Builder
+	for _, word := range strings.Split(name, "_") {
+		if word == "" {
+			continue
+		}
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		sb.WriteString(string(runes))
+	}
+	return sb.String()
+}
+
+// stripNormalizer strips a prefix and a suffix.
+type stripNormalizer struct {
+	prefix string
+	suffix string
+}
+
+// Normalize strips the prefix and the suffix if the name has both of them and something remains.
+func (n stripNormalizer) Normalize(name string) string {
+	if len(name) <= len(n.prefix)+len(n.suffix) ||
+		!strings.HasPrefix(name, n.prefix) || !strings.HasSuffix(name, n.suffix) {
+		return name
+	}
+	return name[len(n.prefix) : len(name)-len(n.suffix)]
+}
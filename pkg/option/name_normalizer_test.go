@@ -0,0 +1,44 @@
+package option_test
+
+import (
+	"testing"
+
+	"github.com/reedom/convergen/v8/pkg/option"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNameNormalizer(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		arg      string
+		name     string
+		expected string
+	}{
+		{arg: "acronym", name: "UserID", expected: "UserId"},
+		{arg: "acronym", name: "URLPath", expected: "UrlPath"},
+		{arg: "acronym", name: "PkID", expected: "PkId"},
+		{arg: "acronym", name: "ID", expected: "Id"},
+		{arg: "acronym", name: "Name", expected: "Name"},
+		{arg: "snake", name: "pk_id", expected: "PkId"},
+		{arg: "snake", name: "User_ID", expected: "UserID"},
+		{arg: "snake", name: "UserID", expected: "UserID"},
+		{arg: "Db*", name: "DbName", expected: "Name"},
+		{arg: "Db*", name: "Db", expected: "Db"},
+		{arg: "Db*", name: "Name", expected: "Name"},
+		{arg: "*Col", name: "NameCol", expected: "Name"},
+		{arg: "X*Col", name: "XNameCol", expected: "Name"},
+	}
+
+	for _, tt := range cases {
+		normalizer, ok := option.NewNameNormalizer(tt.arg)
+		require.True(t, ok, tt.arg)
+		assert.Equal(t, tt.expected, normalizer.Normalize(tt.name), "%v: %v", tt.arg, tt.name)
+	}
+
+	for _, arg := range []string{"", "*", "camel", "Db", "*Db*"} {
+		_, ok := option.NewNameNormalizer(arg)
+		assert.False(t, ok, arg)
+	}
+}
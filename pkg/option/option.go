@@ -1,6 +1,8 @@
 package option
 
 import (
+	"go/types"
+	"slices"
 	"strings"
 
 	"github.com/reedom/convergen/v8/pkg/generator/model"
@@ -8,21 +10,37 @@ import (
 
 // Options represents the conversion options.
 type Options struct {
-	Style               model.DstVarStyle // Style of the destination variable name
-	Rule                model.MatchRule   // Matching rule for fields
-	ExactCase           bool              // Whether to match fields with exact case sensitivity
-	Getter              bool              // Whether to use getter methods to access fields
-	Stringer            bool              // Whether to use stringer methods to convert values to strings
-	Typecast            bool              // Whether to use explicit typecasts when converting values
-	Receiver            string            // Receiver name for method generation
-	Reverse             bool              // Whether to reverse the order of struct tags
-	SkipFields          []*PatternMatcher // List of field names to skip during conversion
-	NameMapper          []*NameMatcher    // List of field name mapping rules
-	TemplatedNameMapper []*NameMatcher    // List of templated field name mapping rules
-	Converters          []*FieldConverter // List of field conversion rules
-	Literals            []*LiteralSetter  // List of literal value setting rules
-	PreProcess          *Manipulator      // Manipulator to run before struct processing
-	PostProcess         *Manipulator      // Manipulator to run after struct processing
+	Style               model.DstVarStyle   // Style of the destination variable name
+	Rule                model.MatchRule     // Matching rule for fields
+	TagKey              string              // Struct tag key to match fields with when Rule is MatchRuleTag
+	ExactCase           bool                // Whether to match fields with exact case sensitivity
+	Normalizers         []NameNormalizer    // Normalizers applied to field names in order before they are compared
+	Getter              bool                // Whether to use getter methods to access fields
+	Stringer            bool                // Whether to use stringer methods to convert values to strings
+	Text                bool                // Whether to convert between strings and the types that marshal and unmarshal text
+	Typecast            bool                // Whether to use explicit typecasts when converting values
+	CheckedTypecast     bool                // Whether to check the range of the numeric typecasts that may overflow
+	NilSafe             bool                // Whether to guard pointer hops in source expressions against nil
+	PtrCast             bool                // Whether to convert between pointers and values
+	Merge               bool                // Whether to match fields in the additional arguments of struct types, too
+	NilPolicy           model.NilPolicy     // How to handle a nil pointer when PtrCast dereferences it
+	Receiver            string              // Receiver name for method generation
+	Reverse             bool                // Whether to reverse the order of struct tags
+	SkipFields          []*PatternMatcher   // List of field names to skip during conversion
+	NameMapper          []*NameMatcher      // List of field name mapping rules
+	TemplatedNameMapper []*NameMatcher      // List of templated field name mapping rules
+	Converters          []*FieldConverter   // List of field conversion rules
+	TypeConverters      []*TypeConverter    // List of type conversion rules
+	StructConverters    []*StructConverter  // List of whole-struct-to-field conversion rules
+	EnumConverters      []*EnumConverter    // List of constant set conversion rules
+	Variants            []*VariantConverter // List of conversion rules for the concrete types of interface values
+	Builtins            []string            // List of the built-in converter groups to apply
+	Flatten             []*FlattenRule      // List of rules to match the fields of a source nested struct with prefixed fields
+	Unflatten           []*FlattenRule      // List of rules to match the fields of a destination nested struct with prefixed fields
+	Literals            []*LiteralSetter    // List of literal value setting rules
+	Fallbacks           []*LiteralSetter    // List of literal values to set when a guarded source is nil
+	PreProcess          *Manipulator        // Manipulator to run before struct processing
+	PostProcess         *Manipulator        // Manipulator to run after struct processing
 }
 
 // NewOptions returns a new Options instance.
@@ -34,9 +52,91 @@ func NewOptions() Options {
 		Getter:    false,
 		Stringer:  false,
 		Typecast:  false,
+		NilPolicy: model.NilPolicyZero,
 	}
 }
 
+// CopierOptions returns a new Options instance for a copier function.
+// A copier function inherits the matching preferences and the type, enum, variant and built-in converters from o,
+// but not the field specific rules since their paths are relative to the root of the convergen method.
+func (o Options) CopierOptions() Options {
+	ret := NewOptions()
+	ret.Rule = o.Rule
+	ret.TagKey = o.TagKey
+	ret.ExactCase = o.ExactCase
+	ret.Normalizers = o.Normalizers
+	ret.Getter = o.Getter
+	ret.Stringer = o.Stringer
+	ret.Text = o.Text
+	ret.Typecast = o.Typecast
+	ret.CheckedTypecast = o.CheckedTypecast
+	ret.NilSafe = o.NilSafe
+	ret.PtrCast = o.PtrCast
+	ret.NilPolicy = o.NilPolicy
+	ret.TypeConverters = o.TypeConverters
+	ret.EnumConverters = o.EnumConverters
+	ret.Variants = o.Variants
+	ret.Builtins = o.Builtins
+	return ret
+}
+
+// SameCopierOptions reports whether o and other have the same options that CopierOptions inherits,
+// so that a copier function built with one of them can be shared with the other.
+func (o Options) SameCopierOptions(other Options) bool {
+	return o.Rule == other.Rule &&
+		o.TagKey == other.TagKey &&
+		o.ExactCase == other.ExactCase &&
+		slices.Equal(o.Normalizers, other.Normalizers) &&
+		o.Getter == other.Getter &&
+		o.Stringer == other.Stringer &&
+		o.Text == other.Text &&
+		o.Typecast == other.Typecast &&
+		o.CheckedTypecast == other.CheckedTypecast &&
+		o.NilSafe == other.NilSafe &&
+		o.PtrCast == other.PtrCast &&
+		o.NilPolicy == other.NilPolicy &&
+		slices.Equal(o.TypeConverters, other.TypeConverters) &&
+		slices.Equal(o.EnumConverters, other.EnumConverters) &&
+		slices.Equal(o.Variants, other.Variants) &&
+		slices.Equal(o.Builtins, other.Builtins)
+}
+
+// LookupTypeConverter returns the first type converter that converts src into dst, or nil.
+func (o Options) LookupTypeConverter(src, dst types.Type) *TypeConverter {
+	for _, converter := range o.TypeConverters {
+		if converter.Match(src, dst) {
+			return converter
+		}
+	}
+	return nil
+}
+
+// LookupEnumConverter returns the first enum converter that converts src into dst, or nil.
+func (o Options) LookupEnumConverter(src, dst types.Type) *EnumConverter {
+	for _, converter := range o.EnumConverters {
+		if converter.Match(src, dst) {
+			return converter
+		}
+	}
+	return nil
+}
+
+// LookupVariants returns the variant converters that convert a concrete value of the src interface into dst.
+func (o Options) LookupVariants(src, dst types.Type) []*VariantConverter {
+	var variants []*VariantConverter
+	for _, converter := range o.Variants {
+		if converter.Match(src, dst) {
+			variants = append(variants, converter)
+		}
+	}
+	return variants
+}
+
+// LookupBuiltinConverter returns the first built-in converter in the enabled groups that converts src into dst, or nil.
+func (o Options) LookupBuiltinConverter(src, dst types.Type) *BuiltinConverter {
+	return lookupBuiltinConverter(o.Builtins, src, dst)
+}
+
 // ShouldSkip returns true if the field with the given name should be skipped.
 func (o Options) ShouldSkip(fieldName string) bool {
 	for _, skip := range o.SkipFields {
@@ -47,8 +147,18 @@ func (o Options) ShouldSkip(fieldName string) bool {
 	return false
 }
 
-// CompareFieldName compares two field names.
+// NormalizeFieldName applies the normalizers to the field name in order.
+func (o Options) NormalizeFieldName(name string) string {
+	for _, normalizer := range o.Normalizers {
+		name = normalizer.Normalize(name)
+	}
+	return name
+}
+
+// CompareFieldName compares two field names after normalizing them.
 func (o Options) CompareFieldName(a, b string) bool {
+	a = o.NormalizeFieldName(a)
+	b = o.NormalizeFieldName(b)
 	if o.ExactCase {
 		return a == b
 	}
@@ -57,40 +167,72 @@ func (o Options) CompareFieldName(a, b string) bool {
 
 // ValidOpsIntf is a set of valid conversion option keys for interface-level conversion.
 var ValidOpsIntf = map[string]struct{}{
-	"convergen":    {},
-	"style":        {},
-	"match":        {},
-	"case":         {},
-	"case:off":     {},
-	"getter":       {},
-	"getter:off":   {},
-	"stringer":     {},
-	"stringer:off": {},
-	"typecast":     {},
-	"typecast:off": {},
+	"convergen":     {},
+	"style":         {},
+	"match":         {},
+	"case":          {},
+	"case:off":      {},
+	"normalize":     {},
+	"normalize:off": {},
+	"getter":        {},
+	"getter:off":    {},
+	"stringer":      {},
+	"stringer:off":  {},
+	"text":          {},
+	"text:off":      {},
+	"typecast":      {},
+	"typecast:off":  {},
+	"nilsafe":       {},
+	"nilsafe:off":   {},
+	"ptrcast":       {},
+	"ptrcast:off":   {},
+	"merge":         {},
+	"merge:off":     {},
+	"conv:type":     {},
+	"enum":          {},
+	"variant":       {},
+	"builtin":       {},
+	"builtin:off":   {},
 }
 
 // ValidOpsMethod is a set of valid conversion option keys for method-level conversion.
 var ValidOpsMethod = map[string]struct{}{
-	"style":        {},
-	"match":        {},
-	"case":         {},
-	"case:off":     {},
-	"getter":       {},
-	"getter:off":   {},
-	"stringer":     {},
-	"stringer:off": {},
-	"typecast":     {},
-	"typecast:off": {},
-	"recv":         {},
-	"reverse":      {},
-	"skip":         {},
-	"map":          {},
-	"tag":          {},
-	"conv":         {},
-	"conv:type":    {},
-	"conv:with":    {},
-	"literal":      {},
-	"preprocess":   {},
-	"postprocess":  {},
+	"style":         {},
+	"match":         {},
+	"case":          {},
+	"case:off":      {},
+	"normalize":     {},
+	"normalize:off": {},
+	"getter":        {},
+	"getter:off":    {},
+	"stringer":      {},
+	"stringer:off":  {},
+	"text":          {},
+	"text:off":      {},
+	"typecast":      {},
+	"typecast:off":  {},
+	"nilsafe":       {},
+	"nilsafe:off":   {},
+	"ptrcast":       {},
+	"ptrcast:off":   {},
+	"merge":         {},
+	"merge:off":     {},
+	"recv":          {},
+	"reverse":       {},
+	"skip":          {},
+	"map":           {},
+	"tag":           {},
+	"conv":          {},
+	"conv:type":     {},
+	"conv:with":     {},
+	"enum":          {},
+	"variant":       {},
+	"builtin":       {},
+	"builtin:off":   {},
+	"flatten":       {},
+	"unflatten":     {},
+	"literal":       {},
+	"fallback":      {},
+	"preprocess":    {},
+	"postprocess":   {},
 }
@@ -0,0 +1,65 @@
+package option
+
+import (
+	"go/token"
+	"go/types"
+)
+
+// StructConverter represents a converter that takes the entire source value, and optionally
+// the additional arguments, to compute the value of a single destination field.
+type StructConverter struct {
+	converter string        // The name of the converter function.
+	dst       *IdentMatcher // The IdentMatcher for the destination.
+	pos       token.Pos     // The position of the converter in the source code.
+
+	argTypes []types.Type // The types of the converter's arguments.
+	retType  types.Type   // The type of the converter's return value.
+	retError bool         // Indicates whether the converter returns an error.
+}
+
+// NewStructConverter creates a new StructConverter with the given parameters.
+func NewStructConverter(converter, dst string, pos token.Pos) *StructConverter {
+	return &StructConverter{
+		converter: converter,
+		dst:       NewIdentMatcher(dst),
+		pos:       pos,
+	}
+}
+
+// Set sets the types of the StructConverter's arguments and return value, as well as whether the converter returns an error.
+func (c *StructConverter) Set(argTypes []types.Type, retType types.Type, returnError bool) {
+	c.argTypes = argTypes
+	c.retType = retType
+	c.retError = returnError
+}
+
+// Converter returns the name of the converter function.
+func (c *StructConverter) Converter() string {
+	return c.converter
+}
+
+// Dst returns the IdentMatcher instance for the destination.
+func (c *StructConverter) Dst() *IdentMatcher {
+	return c.dst
+}
+
+// Pos returns the position of the StructConverter.
+func (c *StructConverter) Pos() token.Pos {
+	return c.pos
+}
+
+// ArgTypes returns the types of the converter's arguments.
+// The first one is for the source value, and the rest are for the additional arguments.
+func (c *StructConverter) ArgTypes() []types.Type {
+	return c.argTypes
+}
+
+// RetType returns the type of the converter's return value.
+func (c *StructConverter) RetType() types.Type {
+	return c.retType
+}
+
+// RetError returns true if the converter returns an error.
+func (c *StructConverter) RetError() bool {
+	return c.retError
+}
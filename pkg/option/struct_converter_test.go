@@ -0,0 +1,26 @@
+package option_test
+
+import (
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/reedom/convergen/v8/pkg/option"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStructConverter(t *testing.T) {
+	sc := option.NewStructConverter("fullName", "Name", token.NoPos)
+
+	assert.Equal(t, "fullName", sc.Converter())
+	assert.Equal(t, "Name", sc.Dst().ExprAt(0))
+	assert.True(t, sc.Dst().Match("Name", true))
+	assert.Equal(t, token.NoPos, sc.Pos())
+
+	argTypes := []types.Type{types.Typ[types.Int], types.Typ[types.Bool]}
+	retType := types.Typ[types.String]
+	sc.Set(argTypes, retType, true)
+	assert.Equal(t, argTypes, sc.ArgTypes())
+	assert.Equal(t, retType, sc.RetType())
+	assert.True(t, sc.RetError())
+}
@@ -0,0 +1,98 @@
+package option
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+)
+
+// TypeConverter represents a converter that applies to every pair of the source and destination types.
+type TypeConverter struct {
+	converter string    // The name of the converter function.
+	src       string    // The type expression of the source.
+	dst       string    // The type expression of the destination. Empty means the converter's return type.
+	pos       token.Pos // The position of the converter in the source code.
+
+	srcType  types.Type // The source type.
+	dstType  types.Type // The destination type.
+	argType  types.Type // The type of the converter's argument.
+	retType  types.Type // The type of the converter's return value.
+	retError bool       // Indicates whether the converter returns an error.
+}
+
+// NewTypeConverter creates a new TypeConverter with the given parameters.
+// dst can be empty.
+func NewTypeConverter(converter, src, dst string, pos token.Pos) *TypeConverter {
+	return &TypeConverter{
+		converter: converter,
+		src:       src,
+		dst:       dst,
+		pos:       pos,
+	}
+}
+
+// Set sets the types of the TypeConverter's argument and return value, as well as whether the converter returns an error.
+func (c *TypeConverter) Set(argType, retType types.Type, returnError bool) {
+	c.argType = argType
+	c.retType = retType
+	c.retError = returnError
+}
+
+// SetTypes sets the source and destination types that the TypeConverter applies to.
+func (c *TypeConverter) SetTypes(srcType, dstType types.Type) {
+	c.srcType = srcType
+	c.dstType = dstType
+}
+
+// Resolved returns true if the types of the TypeConverter have been set.
+func (c *TypeConverter) Resolved() bool {
+	return c.srcType != nil && c.dstType != nil
+}
+
+// Match returns true if the TypeConverter converts src into a value that is assignable to dst.
+func (c *TypeConverter) Match(src, dst types.Type) bool {
+	if !c.Resolved() {
+		return false
+	}
+	return types.Identical(src, c.srcType) && types.AssignableTo(c.dstType, dst)
+}
+
+// Converter returns the name of the converter function.
+func (c *TypeConverter) Converter() string {
+	return c.converter
+}
+
+// Src returns the type expression of the source.
+func (c *TypeConverter) Src() string {
+	return c.src
+}
+
+// Dst returns the type expression of the destination, or empty if it is not specified.
+func (c *TypeConverter) Dst() string {
+	return c.dst
+}
+
+// Pos returns the position of the TypeConverter.
+func (c *TypeConverter) Pos() token.Pos {
+	return c.pos
+}
+
+// ArgType returns the type of the converter's argument.
+func (c *TypeConverter) ArgType() types.Type {
+	return c.argType
+}
+
+// RetType returns the type of the converter's return value.
+func (c *TypeConverter) RetType() types.Type {
+	return c.retType
+}
+
+// RetError returns true if the converter returns an error.
+func (c *TypeConverter) RetError() bool {
+	return c.retError
+}
+
+// RHSExpr returns the right-hand side expression of the TypeConverter for a given argument.
+func (c *TypeConverter) RHSExpr(arg string) string {
+	return fmt.Sprintf("%v(%v)", c.converter, arg)
+}
@@ -0,0 +1,37 @@
+package option_test
+
+import (
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/reedom/convergen/v8/pkg/option"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypeConverter(t *testing.T) {
+	tc := option.NewTypeConverter("myConverter", "int", "", token.NoPos)
+
+	assert.Equal(t, "myConverter", tc.Converter())
+	assert.Equal(t, "int", tc.Src())
+	assert.Equal(t, "", tc.Dst())
+	assert.Equal(t, token.NoPos, tc.Pos())
+	assert.False(t, tc.Resolved())
+	assert.False(t, tc.Match(types.Typ[types.Int], types.Typ[types.String]))
+
+	argType := types.Typ[types.Int]
+	retType := types.Typ[types.String]
+	tc.Set(argType, retType, true)
+	tc.SetTypes(argType, retType)
+	assert.True(t, tc.Resolved())
+	assert.Equal(t, argType, tc.ArgType())
+	assert.Equal(t, retType, tc.RetType())
+	assert.True(t, tc.RetError())
+
+	assert.True(t, tc.Match(types.Typ[types.Int], types.Typ[types.String]))
+	assert.False(t, tc.Match(types.Typ[types.Int64], types.Typ[types.String]))
+	assert.False(t, tc.Match(types.Typ[types.Int], types.Typ[types.Int]))
+	assert.True(t, tc.Match(types.Typ[types.Int], types.NewInterfaceType(nil, nil)))
+
+	assert.Equal(t, "myConverter(42)", tc.RHSExpr("42"))
+}
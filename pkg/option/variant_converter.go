@@ -0,0 +1,78 @@
+package option
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+)
+
+// VariantConverter represents a converter for one of the concrete types that an interface value may hold.
+// The argument type of the converter is the concrete type, and a type switch calls the converter
+// when the value holds it.
+type VariantConverter struct {
+	converter string    // The name of the converter function.
+	pos       token.Pos // The position of the converter in the source code.
+
+	argType  types.Type // The type of the converter's argument, i.e. the concrete type.
+	retType  types.Type // The type of the converter's return value.
+	retError bool       // Indicates whether the converter returns an error.
+}
+
+// NewVariantConverter creates a new VariantConverter with the given parameters.
+func NewVariantConverter(converter string, pos token.Pos) *VariantConverter {
+	return &VariantConverter{
+		converter: converter,
+		pos:       pos,
+	}
+}
+
+// Set sets the types of the VariantConverter's argument and return value, as well as whether the converter returns an error.
+func (c *VariantConverter) Set(argType, retType types.Type, returnError bool) {
+	c.argType = argType
+	c.retType = retType
+	c.retError = returnError
+}
+
+// Resolved returns true if the types of the VariantConverter have been set.
+func (c *VariantConverter) Resolved() bool {
+	return c.argType != nil && c.retType != nil
+}
+
+// Match returns true if src is an interface that the argument type implements and
+// the converter returns a value that is assignable to dst.
+func (c *VariantConverter) Match(src, dst types.Type) bool {
+	if !c.Resolved() || !types.IsInterface(src) {
+		return false
+	}
+	return types.AssignableTo(c.argType, src) && types.AssignableTo(c.retType, dst)
+}
+
+// Converter returns the name of the converter function.
+func (c *VariantConverter) Converter() string {
+	return c.converter
+}
+
+// Pos returns the position of the VariantConverter.
+func (c *VariantConverter) Pos() token.Pos {
+	return c.pos
+}
+
+// ArgType returns the type of the converter's argument.
+func (c *VariantConverter) ArgType() types.Type {
+	return c.argType
+}
+
+// RetType returns the type of the converter's return value.
+func (c *VariantConverter) RetType() types.Type {
+	return c.retType
+}
+
+// RetError returns true if the converter returns an error.
+func (c *VariantConverter) RetError() bool {
+	return c.retError
+}
+
+// RHSExpr returns the right-hand side expression of the VariantConverter for a given argument.
+func (c *VariantConverter) RHSExpr(arg string) string {
+	return fmt.Sprintf("%v(%v)", c.converter, arg)
+}
@@ -0,0 +1,43 @@
+package option_test
+
+import (
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/reedom/convergen/v8/pkg/option"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVariantConverter(t *testing.T) {
+	// type Payment interface { Amount() int }
+	sig := types.NewSignatureType(nil, nil, nil, nil,
+		types.NewTuple(types.NewVar(token.NoPos, nil, "", types.Typ[types.Int])), false)
+	amount := types.NewFunc(token.NoPos, nil, "Amount", sig)
+	payment := types.NewNamed(types.NewTypeName(token.NoPos, nil, "Payment", nil),
+		types.NewInterfaceType([]*types.Func{amount}, nil).Complete(), nil)
+
+	// type Card struct{}; func (Card) Amount() int
+	card := types.NewNamed(types.NewTypeName(token.NoPos, nil, "Card", nil), types.NewStruct(nil, nil), nil)
+	recv := types.NewVar(token.NoPos, nil, "", card)
+	card.AddMethod(types.NewFunc(token.NoPos, nil, "Amount", types.NewSignatureType(recv, nil, nil, nil, sig.Results(), false)))
+
+	vc := option.NewVariantConverter("fromCard", token.NoPos)
+	assert.Equal(t, "fromCard", vc.Converter())
+	assert.Equal(t, token.NoPos, vc.Pos())
+	assert.False(t, vc.Resolved())
+	assert.False(t, vc.Match(payment, types.Typ[types.String]))
+
+	vc.Set(card, types.Typ[types.String], true)
+	assert.True(t, vc.Resolved())
+	assert.Equal(t, card, vc.ArgType())
+	assert.Equal(t, types.Typ[types.String], vc.RetType())
+	assert.True(t, vc.RetError())
+
+	assert.True(t, vc.Match(payment, types.Typ[types.String]))
+	assert.True(t, vc.Match(types.NewInterfaceType(nil, nil), types.Typ[types.String]))
+	assert.False(t, vc.Match(card, types.Typ[types.String]))
+	assert.False(t, vc.Match(payment, types.Typ[types.Int]))
+
+	assert.Equal(t, "fromCard(v)", vc.RHSExpr("v"))
+}
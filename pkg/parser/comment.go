@@ -1,12 +1,15 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
 	"go/ast"
 	"go/token"
 	"go/types"
 	"regexp"
+	"sort"
 	"strings"
+	"unicode"
 
 	bmodel "github.com/reedom/convergen/v8/pkg/builder/model"
 	gmodel "github.com/reedom/convergen/v8/pkg/generator/model"
@@ -28,137 +31,367 @@ var (
 
 // parseNotationInComments parses given notations and set the values into given Options.
 // validOps is a map of valid operation names.
+// It parses all the notations even if some of them are invalid, and returns their errors joined.
 func (p *Parser) parseNotationInComments(notations []*ast.Comment, validOps map[string]struct{}, opts *option.Options) error {
 	var posReverse token.Pos
+	var errs []error
 
+	numEnums := len(opts.EnumConverters)
 	for _, n := range notations {
-		m := reNotation.FindStringSubmatch(n.Text)
-		if m == nil || len(m) < 2 {
-			return fmt.Errorf("invalid notation format %#v", m)
+		if err := p.parseNotation(n, validOps, opts, &posReverse); err != nil {
+			errs = append(errs, err)
 		}
+	}
 
-		var args []string
-		if len(m) == 3 {
-			args = strings.Fields(m[2])
+	// Pair the constants of the enum converters by the case setting that the notations end up with,
+	// so that ":case" and ":case:off" take effect regardless of their order to ":enum".
+	for _, conv := range opts.EnumConverters[numEnums:] {
+		if err := p.resolveEnumConverter(conv, opts.ExactCase); err != nil {
+			errs = append(errs, err)
 		}
+	}
 
-		if _, ok := validOps[m[1]]; !ok {
-			logger.Printf(`%v: ":%v" is invalid or unknown notation here`, p.fset.Position(n.Pos()), m[1])
-			continue
-		}
+	// validation
+	if opts.Reverse && opts.Style == gmodel.DstVarReturn {
+		errs = append(errs, p.logger.Report(logger.Diagnostic{
+			Pos:      p.fset.Position(posReverse),
+			Severity: logger.SeverityError,
+			Code:     logger.CodeConflictingNotations,
+			Message:  `to use ":reverse", style must be ":style arg"`,
+			Fix:      `add ":style arg"`,
+		}))
+	}
+	return errors.Join(errs...)
+}
 
-		switch m[1] {
-		case "convergen":
-			// do nothing
-		case "style":
-			if len(args) == 0 {
-				return logger.Errorf("%v: needs <style> arg", p.fset.Position(n.Pos()))
-			} else if style, ok := gmodel.NewDstVarStyleFromValue(args[0]); !ok {
-				return logger.Errorf("%v: invalid <style> arg", p.fset.Position(n.Pos()))
-			} else {
-				opts.Style = style
-			}
-		case "match":
-			if len(args) == 0 {
-				return logger.Errorf("%v: needs <algorithm> arg", p.fset.Position(n.Pos()))
-			} else if rule, ok := gmodel.NewMatchRuleFromValue(args[0]); !ok {
-				return logger.Errorf("%v: invalid <algorithm> arg", p.fset.Position(n.Pos()))
-			} else {
-				opts.Rule = rule
-			}
-		case "case":
-			opts.ExactCase = true
-		case "case:off":
-			opts.ExactCase = false
-		case "getter":
-			opts.Getter = true
-		case "getter:off":
-			opts.Getter = false
-		case "stringer":
-			opts.Stringer = true
-		case "stringer:off":
-			opts.Stringer = false
-		case "typecast":
-			opts.Typecast = true
-		case "typecast:off":
-			opts.Typecast = false
-		case "recv":
-			if len(args) == 0 {
-				return logger.Errorf("%v: needs name for the receiver", p.fset.Position(n.Pos()))
-			} else if !isValidIdentifier(args[0]) {
-				return logger.Errorf("%v: invalid ident", p.fset.Position(n.Pos()))
-			}
-			opts.Receiver = args[0]
-		case "reverse":
-			opts.Reverse = true
-			posReverse = n.Pos()
-		case "skip":
-			if len(args) == 0 {
-				return logger.Errorf("%v: needs <field> arg", p.fset.Position(n.Pos()))
-			}
-			matcher, err := option.NewPatternMatcher(args[0], opts.ExactCase)
-			if err != nil {
-				return logger.Errorf("%v: invalid regexp", p.fset.Position(n.Pos()))
-			}
-			opts.SkipFields = append(opts.SkipFields, matcher)
-		case "map":
-			if len(args) < 2 {
-				return logger.Errorf("%v: needs <src> <dst> args", p.fset.Position(n.Pos()))
-			}
-			src := args[0]
-			dst := args[1]
-			matcher := option.NewNameMatcher(src, dst, n.Pos())
-			if strings.HasPrefix(src, "$") {
-				opts.TemplatedNameMapper = append(opts.TemplatedNameMapper, matcher)
-			} else {
-				opts.NameMapper = append(opts.NameMapper, matcher)
-			}
-		case "conv":
-			if len(args) < 2 {
-				return logger.Errorf("%v: needs <src> <dst> args", p.fset.Position(n.Pos()))
+// parseNotation parses a notation and sets the value into given Options.
+// posReverse receives the position of ":reverse".
+func (p *Parser) parseNotation(n *ast.Comment, validOps map[string]struct{}, opts *option.Options, posReverse *token.Pos) error {
+	m := reNotation.FindStringSubmatch(n.Text)
+	if m == nil || len(m) < 2 {
+		return fmt.Errorf("invalid notation format %#v", m)
+	}
+
+	var args []string
+	if len(m) == 3 {
+		args = strings.Fields(m[2])
+	}
+
+	if _, ok := validOps[m[1]]; !ok {
+		p.warnInvalidNotation(n, m[1], validOps)
+		return nil
+	}
+
+	switch m[1] {
+	case "convergen":
+		// do nothing
+	case "style":
+		if len(args) == 0 {
+			return p.logger.ErrorAt(p.fset.Position(n.Pos()), logger.CodeMissingArgument, "needs <style> arg")
+		} else if style, ok := gmodel.NewDstVarStyleFromValue(args[0]); !ok {
+			return p.logger.ErrorAt(p.fset.Position(n.Pos()), logger.CodeInvalidArgument, "invalid <style> arg")
+		} else {
+			opts.Style = style
+		}
+	case "match":
+		if len(args) == 0 {
+			return p.logger.ErrorAt(p.fset.Position(n.Pos()), logger.CodeMissingArgument, "needs <algorithm> arg")
+		} else if rule, ok := gmodel.NewMatchRuleFromValue(args[0]); !ok {
+			return p.logger.ErrorAt(p.fset.Position(n.Pos()), logger.CodeInvalidArgument, "invalid <algorithm> arg")
+		} else if rule == gmodel.MatchRuleTag && len(args) < 2 {
+			return p.logger.ErrorAt(p.fset.Position(n.Pos()), logger.CodeMissingArgument, "needs <tag key> arg for tag match")
+		} else {
+			opts.Rule = rule
+			opts.TagKey = ""
+			if rule == gmodel.MatchRuleTag {
+				opts.TagKey = args[1]
 			}
-			src := args[1]
-			dst := src
-			if 3 <= len(args) {
-				dst = args[2]
+		}
+	case "case":
+		opts.ExactCase = true
+	case "case:off":
+		opts.ExactCase = false
+	case "normalize":
+		if len(args) == 0 {
+			return p.logger.ErrorAt(p.fset.Position(n.Pos()), logger.CodeMissingArgument, "needs <normalizer> args")
+		}
+		var normalizers []option.NameNormalizer
+		for _, arg := range args {
+			normalizer, ok := option.NewNameNormalizer(arg)
+			if !ok {
+				return p.logger.ErrorAt(p.fset.Position(n.Pos()), logger.CodeInvalidArgument, "invalid <normalizer> arg %v", arg)
 			}
-			converter := option.NewFieldConverter(args[0], src, dst, n.Pos())
-			opts.Converters = append(opts.Converters, converter)
-		case "literal":
-			if len(args) < 2 {
-				return logger.Errorf("%v: needs <dst> <literal> args", p.fset.Position(n.Pos()))
+			normalizers = append(normalizers, normalizer)
+		}
+		opts.Normalizers = appendOpt(opts.Normalizers, normalizers...)
+	case "normalize:off":
+		opts.Normalizers = nil
+	case "getter":
+		opts.Getter = true
+	case "getter:off":
+		opts.Getter = false
+	case "stringer":
+		opts.Stringer = true
+	case "stringer:off":
+		opts.Stringer = false
+	case "text":
+		opts.Text = true
+		p.addImport(option.StdconvPkgPath)
+	case "text:off":
+		opts.Text = false
+	case "typecast":
+		opts.Typecast = true
+		opts.CheckedTypecast = false
+		if 0 < len(args) {
+			if args[0] != "checked" {
+				return p.logger.ErrorAt(p.fset.Position(n.Pos()), logger.CodeInvalidArgument, "invalid <mode> arg")
 			}
-			m = reLiteral.FindStringSubmatch(m[2])
-			setter := option.NewLiteralSetter(args[0], m[1], n.Pos())
-			opts.Literals = append(opts.Literals, setter)
-		case "preprocess":
-			if len(args) < 1 {
-				return logger.Errorf("%v: needs <func> arg", p.fset.Position(n.Pos()))
+			opts.CheckedTypecast = true
+			p.addImport(option.StdconvPkgPath)
+		}
+	case "typecast:off":
+		opts.Typecast = false
+		opts.CheckedTypecast = false
+	case "nilsafe":
+		opts.NilSafe = true
+	case "nilsafe:off":
+		opts.NilSafe = false
+	case "ptrcast":
+		opts.PtrCast = true
+		if 0 < len(args) {
+			policy, ok := gmodel.NewNilPolicyFromValue(args[0])
+			if !ok {
+				return p.logger.ErrorAt(p.fset.Position(n.Pos()), logger.CodeInvalidArgument, "invalid <nil policy> arg")
 			}
-			pp, err := p.lookupManipulatorFunc(args[0], "preprocess", n.Pos())
-			if err != nil {
-				return err
+			opts.NilPolicy = policy
+		}
+	case "ptrcast:off":
+		opts.PtrCast = false
+	case "merge":
+		opts.Merge = true
+	case "merge:off":
+		opts.Merge = false
+	case "recv":
+		if len(args) == 0 {
+			return p.logger.ErrorAt(p.fset.Position(n.Pos()), logger.CodeMissingArgument, "needs name for the receiver")
+		} else if !isValidIdentifier(args[0]) {
+			return p.logger.ErrorAt(p.fset.Position(n.Pos()), logger.CodeInvalidArgument, "invalid ident")
+		}
+		opts.Receiver = args[0]
+	case "reverse":
+		opts.Reverse = true
+		*posReverse = n.Pos()
+	case "skip":
+		if len(args) == 0 {
+			return p.logger.ErrorAt(p.fset.Position(n.Pos()), logger.CodeMissingArgument, "needs <field> arg")
+		}
+		matcher, err := option.NewPatternMatcher(args[0], opts.ExactCase)
+		if err != nil {
+			return p.logger.ErrorAt(p.fset.Position(n.Pos()), logger.CodeInvalidArgument, "invalid regexp")
+		}
+		opts.SkipFields = append(opts.SkipFields, matcher)
+	case "map":
+		if len(args) < 2 {
+			return p.logger.ErrorAt(p.fset.Position(n.Pos()), logger.CodeMissingArgument, "needs <src> <dst> args")
+		}
+		src := args[0]
+		dst := args[1]
+		matcher := option.NewNameMatcher(src, dst, n.Pos())
+		if strings.HasPrefix(src, "$") {
+			opts.TemplatedNameMapper = append(opts.TemplatedNameMapper, matcher)
+		} else {
+			opts.NameMapper = append(opts.NameMapper, matcher)
+		}
+	case "conv":
+		if len(args) < 2 {
+			return p.logger.ErrorAt(p.fset.Position(n.Pos()), logger.CodeMissingArgument, "needs <src> <dst> args")
+		}
+		src := args[1]
+		dst := src
+		if 3 <= len(args) {
+			dst = args[2]
+		}
+		converter := option.NewFieldConverter(args[0], src, dst, n.Pos())
+		opts.Converters = append(opts.Converters, converter)
+	case "conv:with":
+		if len(args) < 2 {
+			return p.logger.ErrorAt(p.fset.Position(n.Pos()), logger.CodeMissingArgument, "needs <func> <dst> args")
+		}
+		argTypes, retType, retError, err := p.lookupStructConverterFunc(args[0], n.Pos())
+		if err != nil {
+			return err
+		}
+		converter := option.NewStructConverter(args[0], args[1], n.Pos())
+		converter.Set(argTypes, retType, retError)
+		opts.StructConverters = append(opts.StructConverters, converter)
+	case "conv:type":
+		if len(args) < 2 {
+			return p.logger.ErrorAt(p.fset.Position(n.Pos()), logger.CodeMissingArgument, "needs <func> <src type> args")
+		}
+		dst := ""
+		if 3 <= len(args) {
+			dst = args[2]
+		}
+		converter := option.NewTypeConverter(args[0], args[1], dst, n.Pos())
+		opts.TypeConverters = appendOpt(opts.TypeConverters, converter)
+	case "enum":
+		if len(args) < 2 {
+			return p.logger.ErrorAt(p.fset.Position(n.Pos()), logger.CodeMissingArgument, "needs <src type> <dst type> args")
+		}
+		var patterns [2]string
+		for i, pattern := range args[2:] {
+			if len(patterns) <= i || !option.ValidEnumPattern(pattern) {
+				return p.logger.ErrorAt(p.fset.Position(n.Pos()), logger.CodeInvalidArgument, "invalid <pattern> arg %v", pattern)
 			}
-			opts.PreProcess = pp
-		case "postprocess":
-			if len(args) < 1 {
-				return logger.Errorf("%v: needs <func> arg", p.fset.Position(n.Pos()))
+			patterns[i] = pattern
+		}
+		converter := option.NewEnumConverter(args[0], args[1], patterns[0], patterns[1], n.Pos())
+		opts.EnumConverters = appendOpt(opts.EnumConverters, converter)
+	case "variant":
+		if len(args) < 1 {
+			return p.logger.ErrorAt(p.fset.Position(n.Pos()), logger.CodeMissingArgument, "needs <func> arg")
+		}
+		converter := option.NewVariantConverter(args[0], n.Pos())
+		opts.Variants = appendOpt(opts.Variants, converter)
+	case "builtin":
+		// Accept both of ":builtin time,strconv" and ":builtin time strconv".
+		groups := strings.FieldsFunc(m[2], func(r rune) bool { return r == ',' || unicode.IsSpace(r) })
+		if len(groups) == 0 {
+			return p.logger.ErrorAt(p.fset.Position(n.Pos()), logger.CodeMissingArgument, "needs <group> args")
+		}
+		var builtins []string
+		for _, group := range groups {
+			if !option.ValidBuiltinGroup(group) {
+				return p.logger.ErrorAt(p.fset.Position(n.Pos()), logger.CodeInvalidArgument, "invalid <group> arg %v, it should be one of %v", group, strings.Join(option.BuiltinGroups(), ", "))
 			}
-			pp, err := p.lookupManipulatorFunc(args[0], "postprocess", n.Pos())
-			if err != nil {
-				return err
+			for _, pkgPath := range option.BuiltinPkgPaths(group) {
+				p.addImport(pkgPath)
 			}
-			opts.PostProcess = pp
-		default:
-			fmt.Printf("%v: unknown notation %v\n", p.fset.Position(n.Pos()), m[1])
+			builtins = append(builtins, group)
+		}
+		opts.Builtins = appendOpt(opts.Builtins, builtins...)
+	case "builtin:off":
+		opts.Builtins = nil
+	case "flatten":
+		if len(args) < 1 {
+			return p.logger.ErrorAt(p.fset.Position(n.Pos()), logger.CodeMissingArgument, "needs <src field> arg")
+		}
+		prefix := ""
+		if 2 <= len(args) {
+			prefix = args[1]
+		}
+		opts.Flatten = append(opts.Flatten, option.NewFlattenRule(args[0], prefix, n.Pos()))
+	case "unflatten":
+		if len(args) < 1 {
+			return p.logger.ErrorAt(p.fset.Position(n.Pos()), logger.CodeMissingArgument, "needs <dst field> arg")
+		}
+		prefix := ""
+		if 2 <= len(args) {
+			prefix = args[1]
 		}
+		opts.Unflatten = append(opts.Unflatten, option.NewFlattenRule(args[0], prefix, n.Pos()))
+	case "literal":
+		if len(args) < 2 {
+			return p.logger.ErrorAt(p.fset.Position(n.Pos()), logger.CodeMissingArgument, "needs <dst> <literal> args")
+		}
+		m = reLiteral.FindStringSubmatch(m[2])
+		setter := option.NewLiteralSetter(args[0], m[1], n.Pos())
+		opts.Literals = append(opts.Literals, setter)
+	case "fallback":
+		if len(args) < 2 {
+			return p.logger.ErrorAt(p.fset.Position(n.Pos()), logger.CodeMissingArgument, "needs <dst> <literal> args")
+		}
+		m = reLiteral.FindStringSubmatch(m[2])
+		setter := option.NewLiteralSetter(args[0], m[1], n.Pos())
+		opts.Fallbacks = append(opts.Fallbacks, setter)
+	case "preprocess":
+		if len(args) < 1 {
+			return p.logger.ErrorAt(p.fset.Position(n.Pos()), logger.CodeMissingArgument, "needs <func> arg")
+		}
+		pp, err := p.lookupManipulatorFunc(args[0], "preprocess", n.Pos())
+		if err != nil {
+			return err
+		}
+		opts.PreProcess = pp
+	case "postprocess":
+		if len(args) < 1 {
+			return p.logger.ErrorAt(p.fset.Position(n.Pos()), logger.CodeMissingArgument, "needs <func> arg")
+		}
+		pp, err := p.lookupManipulatorFunc(args[0], "postprocess", n.Pos())
+		if err != nil {
+			return err
+		}
+		opts.PostProcess = pp
+	default:
+		p.logger.WarnAt(p.fset.Position(n.Pos()), logger.CodeUnknownNotation, "unknown notation %v", m[1])
 	}
+	return nil
+}
 
-	// validation
-	if opts.Reverse && opts.Style == gmodel.DstVarReturn {
-		return logger.Errorf(`%v: to use ":reverse", style must be ":style arg"`, p.fset.Position(posReverse))
+// warnInvalidNotation reports the notation that is unknown or unavailable in the location,
+// and suggests the location or the notation that the author may mean.
+func (p *Parser) warnInvalidNotation(n *ast.Comment, name string, validOps map[string]struct{}) {
+	d := logger.Diagnostic{
+		Pos:      p.fset.Position(n.Pos()),
+		Severity: logger.SeverityWarning,
+		Code:     logger.CodeMisplacedNotation,
 	}
-	return nil
+	if _, ok := option.ValidOpsMethod[name]; ok {
+		d.Message = fmt.Sprintf(`":%v" is not available for an interface`, name)
+		d.Fix = "move it to the doc comments of the methods"
+	} else if _, ok = option.ValidOpsIntf[name]; ok {
+		d.Message = fmt.Sprintf(`":%v" is not available for a method`, name)
+		d.Fix = "move it to the doc comment of the interface"
+	} else {
+		d.Code = logger.CodeUnknownNotation
+		d.Message = fmt.Sprintf(`":%v" is unknown notation`, name)
+		if similar := similarNotation(name, validOps); similar != "" {
+			d.Fix = fmt.Sprintf(`did you mean ":%v"?`, similar)
+		}
+	}
+	_ = p.logger.Report(d)
+}
+
+// appendOpt appends v to the option slice s without writing to the backing array of s,
+// since a method's options share their slices with the ones of its interface.
+func appendOpt[T any](s []T, v ...T) []T {
+	return append(s[:len(s):len(s)], v...)
+}
+
+// similarNotation returns the valid notation that is the most similar to name within a few typos, or "".
+func similarNotation(name string, validOps map[string]struct{}) string {
+	const maxDistance = 2
+	similar := ""
+	minDistance := maxDistance + 1
+	for op := range validOps {
+		d := editDistance(name, op)
+		if d < minDistance || (d == minDistance && op < similar) {
+			similar = op
+			minDistance = d
+		}
+	}
+	return similar
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
 }
 
 // lookupType looks up a type by name in the current package or its imports.
@@ -186,32 +419,44 @@ func (p *Parser) lookupType(typeName string, pos token.Pos) (*types.Scope, types
 	return scope, obj
 }
 
-// resolveConverters resolves the types and error flag of the FieldConverter `conv` by
+// converterResolver is a converter whose function types are resolved by resolveConverters.
+type converterResolver interface {
+	Converter() string
+	Pos() token.Pos
+	Set(argType, retType types.Type, retError bool)
+}
+
+// resolveConverters resolves the types and error flag of the converter `conv` by
 // looking up the corresponding function based on the converter's name. If the function
 // is found, its argument and return types are set to `conv`. If not, it tries to find
 // a method in `generatingMethods` that can be used as a converter.
 //
 // If no function or method is found, an error is returned.
-func (p *Parser) resolveConverters(generatingMethods []*bmodel.MethodEntry, conv *option.FieldConverter) error {
+func (p *Parser) resolveConverters(generatingMethods []*bmodel.MethodEntry, conv converterResolver) error {
 	name := conv.Converter()
 	pos := conv.Pos()
-	argType, retType, retError, err := p.lookupConverterFunc(name, pos)
-	if err == nil {
+	if _, obj := p.lookupType(name, pos); obj != nil {
+		argType, retType, retError, err := p.lookupConverterFunc(name, pos)
+		if err != nil {
+			return err
+		}
 		conv.Set(argType, retType, retError)
 		return nil
 	}
 
+	// The function may be one of the methods to be generated, which go/types doesn't know.
+	var err error
 	for _, method := range generatingMethods {
 		if method.Name() != name {
 			continue
 		}
 		if method.Opts.Style != gmodel.DstVarReturn {
-			err = logger.Errorf("%v: function %v cannot use as a converter", p.fset.Position(pos), name)
+			err = p.logger.ErrorAt(p.fset.Position(pos), logger.CodeInvalidFunction, "function %v cannot use as a converter", name)
 			continue
 		}
 		if method.Recv() != nil {
 			// TODO(reedom): we may accept a method as a converter.
-			err = logger.Errorf("%v: function %v cannot use as a converter", p.fset.Position(pos), name)
+			err = p.logger.ErrorAt(p.fset.Position(pos), logger.CodeInvalidFunction, "function %v cannot use as a converter", name)
 			continue
 		}
 		conv.Set(method.SrcVar().Type(), method.DstVar().Type(), method.RetError())
@@ -219,37 +464,205 @@ func (p *Parser) resolveConverters(generatingMethods []*bmodel.MethodEntry, conv
 	}
 
 	if err == nil {
-		err = logger.Errorf("%v: function %v not found", p.fset.Position(pos), name)
+		err = p.logger.ErrorAt(p.fset.Position(pos), logger.CodeNotFound, "function %v not found", name)
 	}
 	return err
 }
 
+// resolveTypeConverter resolves the converter function of the TypeConverter `conv` as
+// resolveConverters does, then resolves its source and destination types.
+// The source type must be assignable to the argument of the function, and the return type
+// of the function must be assignable to the destination type.
+// If the destination type is omitted, the return type is used instead.
+func (p *Parser) resolveTypeConverter(generatingMethods []*bmodel.MethodEntry, conv *option.TypeConverter) error {
+	if conv.Resolved() {
+		// An interface-level converter is shared among the methods.
+		return nil
+	}
+	if err := p.resolveConverters(generatingMethods, conv); err != nil {
+		return err
+	}
+
+	pos := conv.Pos()
+	srcType, err := p.lookupTypeExpr(conv.Src(), pos)
+	if err != nil {
+		return err
+	}
+	if !types.AssignableTo(srcType, conv.ArgType()) {
+		return p.logger.ErrorAt(p.fset.Position(pos), logger.CodeInvalidFunction, "function %v cannot take %v", conv.Converter(), conv.Src())
+	}
+
+	dstType := conv.RetType()
+	if conv.Dst() != "" {
+		dstType, err = p.lookupTypeExpr(conv.Dst(), pos)
+		if err != nil {
+			return err
+		}
+		if !types.AssignableTo(conv.RetType(), dstType) {
+			return p.logger.ErrorAt(p.fset.Position(pos), logger.CodeInvalidFunction, "function %v cannot return %v", conv.Converter(), conv.Dst())
+		}
+	}
+	conv.SetTypes(srcType, dstType)
+	return nil
+}
+
+// resolveVariantConverter resolves the converter function of the VariantConverter `conv` as
+// resolveConverters does. The argument of the function must be a concrete type, which becomes
+// a case of the type switch.
+func (p *Parser) resolveVariantConverter(generatingMethods []*bmodel.MethodEntry, conv *option.VariantConverter) error {
+	if conv.Resolved() {
+		// An interface-level converter is shared among the methods.
+		return nil
+	}
+	if err := p.resolveConverters(generatingMethods, conv); err != nil {
+		return err
+	}
+	if types.IsInterface(conv.ArgType()) {
+		return p.logger.ErrorAt(p.fset.Position(conv.Pos()), logger.CodeInvalidFunction, "function %v must take a concrete type", conv.Converter())
+	}
+	return nil
+}
+
+// lookupTypeExpr evaluates a type expression such as "time.Time" or "*model.User"
+// in the scope of the setup file.
+func (p *Parser) lookupTypeExpr(expr string, pos token.Pos) (types.Type, error) {
+	tv, err := types.Eval(p.fset, p.pkg.Types, pos, expr)
+	if err != nil || !tv.IsType() {
+		return nil, p.logger.ErrorAt(p.fset.Position(pos), logger.CodeNotFound, "type %v not found", expr)
+	}
+	return tv.Type, nil
+}
+
+// resolveEnumConverter resolves the types of the enum converter and pairs their constants.
+func (p *Parser) resolveEnumConverter(conv *option.EnumConverter, exactCase bool) error {
+	pos := conv.Pos()
+	srcType, err := p.lookupTypeExpr(conv.Src(), pos)
+	if err != nil {
+		return err
+	}
+	dstType, err := p.lookupTypeExpr(conv.Dst(), pos)
+	if err != nil {
+		return err
+	}
+	srcConsts, err := p.lookupEnumConsts(srcType, conv.Src(), pos)
+	if err != nil {
+		return err
+	}
+	dstConsts, err := p.lookupEnumConsts(dstType, conv.Dst(), pos)
+	if err != nil {
+		return err
+	}
+
+	if err = conv.Pair(srcConsts, dstConsts, exactCase); err != nil {
+		return p.logger.ErrorAt(p.fset.Position(pos), logger.CodeUnsupportedConversion, "cannot convert %v to %v: %v", conv.Src(), conv.Dst(), err)
+	}
+	conv.SetTypes(srcType, dstType)
+	return nil
+}
+
+// lookupEnumConsts returns the constants of the named type in the order of their declarations.
+// Unexported constants in another package are excluded since the generated code cannot refer them.
+func (p *Parser) lookupEnumConsts(typ types.Type, expr string, pos token.Pos) ([]*types.Const, error) {
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok || !util.IsBasicType(named.Underlying()) {
+		return nil, p.logger.ErrorAt(p.fset.Position(pos), logger.CodeInvalidArgument, "%v is not a named basic type", expr)
+	}
+
+	pkg := named.Obj().Pkg()
+	scope := pkg.Scope()
+	var consts []*types.Const
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if !ok || !types.Identical(c.Type(), typ) {
+			continue
+		}
+		if pkg != p.pkg.Types && !c.Exported() {
+			continue
+		}
+		consts = append(consts, c)
+	}
+	if len(consts) == 0 {
+		return nil, p.logger.ErrorAt(p.fset.Position(pos), logger.CodeInvalidArgument, "%v has no constants", expr)
+	}
+
+	sort.SliceStable(consts, func(i, j int) bool {
+		return consts[i].Pos() < consts[j].Pos()
+	})
+	return consts, nil
+}
+
 // lookupConverterFunc finds and returns the argument and return types of a function
 // with the given name and position.
 // It checks that the function is a valid converter function and can be used as such.
 func (p *Parser) lookupConverterFunc(funcName string, pos token.Pos) (argType, retType types.Type, retError bool, err error) {
+	sig, err := p.lookupFuncSignature(funcName, pos)
+	if err != nil {
+		return
+	}
+	if sig.Params().Len() != 1 {
+		err = p.logger.ErrorAt(p.fset.Position(pos), logger.CodeInvalidFunction, "function %v cannot use as a converter", funcName)
+		return
+	}
+	retType, retError, err = p.converterResults(sig, funcName, pos)
+	if err != nil {
+		return
+	}
+
+	argType = sig.Params().At(0).Type()
+	return
+}
+
+// lookupStructConverterFunc finds and returns the argument and return types of a function
+// for ":conv:with" with the given name and position.
+// The function takes the source value and optionally the additional arguments.
+func (p *Parser) lookupStructConverterFunc(funcName string, pos token.Pos) (argTypes []types.Type, retType types.Type, retError bool, err error) {
+	sig, err := p.lookupFuncSignature(funcName, pos)
+	if err != nil {
+		return
+	}
+	if sig.Params().Len() == 0 || sig.Variadic() {
+		err = p.logger.ErrorAt(p.fset.Position(pos), logger.CodeInvalidFunction, "function %v cannot use as a converter", funcName)
+		return
+	}
+	retType, retError, err = p.converterResults(sig, funcName, pos)
+	if err != nil {
+		return
+	}
+
+	argTypes = make([]types.Type, sig.Params().Len())
+	for i := 0; i < sig.Params().Len(); i++ {
+		argTypes[i] = sig.Params().At(i).Type()
+	}
+	return
+}
+
+// lookupFuncSignature looks up a function by name and returns its signature.
+func (p *Parser) lookupFuncSignature(funcName string, pos token.Pos) (*types.Signature, error) {
 	_, obj := p.lookupType(funcName, pos)
 	if obj == nil {
-		err = logger.Errorf("%v: function %v not found", p.fset.Position(pos), funcName)
-		return
+		return nil, p.logger.ErrorAt(p.fset.Position(pos), logger.CodeNotFound, "function %v not found", funcName)
 	}
 	sig, ok := obj.Type().(*types.Signature)
 	if !ok {
-		err = logger.Errorf("%v: %v isn't a function", p.fset.Position(pos), funcName)
-		return
+		return nil, p.logger.ErrorAt(p.fset.Position(pos), logger.CodeInvalidArgument, "%v isn't a function", funcName)
 	}
-	if sig.Params().Len() != 1 || sig.Results().Len() < 1 || 2 < sig.Results().Len() {
-		err = logger.Errorf("%v: function %v cannot use as a converter", p.fset.Position(pos), funcName)
+	return sig, nil
+}
+
+// converterResults checks that the results of a converter function are either (value) or (value, error),
+// and returns the type of the value and whether the function returns an error.
+func (p *Parser) converterResults(sig *types.Signature, funcName string, pos token.Pos) (retType types.Type, retError bool, err error) {
+	if sig.Results().Len() < 1 || 2 < sig.Results().Len() {
+		err = p.logger.ErrorAt(p.fset.Position(pos), logger.CodeInvalidFunction, "function %v cannot use as a converter", funcName)
 		return
 	}
 	if sig.Results().Len() == 2 && !util.IsErrorType(sig.Results().At(1).Type()) {
-		err = logger.Errorf("%v: function %v cannot use as a converter", p.fset.Position(pos), funcName)
+		err = p.logger.ErrorAt(p.fset.Position(pos), logger.CodeInvalidFunction, "function %v cannot use as a converter", funcName)
 		return
 	}
 
-	argType = sig.Params().At(0).Type()
 	retType = sig.Results().At(0).Type()
-	retError = sig.Results().Len() == 2 && util.IsErrorType(sig.Results().At(1).Type())
+	retError = sig.Results().Len() == 2
 	return
 }
 
@@ -259,16 +672,16 @@ func (p *Parser) lookupConverterFunc(funcName string, pos token.Pos) (argType, r
 func (p *Parser) lookupManipulatorFunc(funcName, optName string, pos token.Pos) (*option.Manipulator, error) {
 	_, obj := p.lookupType(funcName, pos)
 	if obj == nil {
-		return nil, logger.Errorf("%v: function %v not found", p.fset.Position(pos), funcName)
+		return nil, p.logger.ErrorAt(p.fset.Position(pos), logger.CodeNotFound, "function %v not found", funcName)
 	}
 	sig, ok := obj.Type().(*types.Signature)
 	if !ok {
-		return nil, logger.Errorf("%v: %v isn't a function", p.fset.Position(pos), funcName)
+		return nil, p.logger.ErrorAt(p.fset.Position(pos), logger.CodeInvalidArgument, "%v isn't a function", funcName)
 	}
 
 	if 1 < sig.Results().Len() ||
 		(sig.Results().Len() == 1 && !util.IsErrorType(sig.Results().At(0).Type())) {
-		return nil, logger.Errorf("%v: function %v cannot use for %v func", p.fset.Position(pos), funcName, optName)
+		return nil, p.logger.ErrorAt(p.fset.Position(pos), logger.CodeInvalidFunction, "function %v cannot use for %v func", funcName, optName)
 	}
 
 	additionalArgs := make([]types.Type, sig.Params().Len()-2)
@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/reedom/convergen/v8/pkg/generator/model"
+	"github.com/reedom/convergen/v8/pkg/logger"
 	"github.com/reedom/convergen/v8/pkg/option"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -42,12 +43,18 @@ func testCommonNotations(t *testing.T, validOpts map[string]struct{}) {
 			expected: func(opt *option.Options) { opt.Style = model.DstVarReturn },
 		},
 		{
-			notation: ":match tag",
-			expected: func(opt *option.Options) { opt.Rule = model.MatchRuleTag },
+			notation: ":match tag json",
+			expected: func(opt *option.Options) {
+				opt.Rule = model.MatchRuleTag
+				opt.TagKey = "json"
+			},
 		},
 		{
 			notation: ":match name",
-			expected: func(opt *option.Options) { opt.Rule = model.MatchRuleName },
+			expected: func(opt *option.Options) {
+				opt.Rule = model.MatchRuleName
+				opt.TagKey = ""
+			},
 		},
 		{
 			notation: ":match none",
@@ -61,6 +68,18 @@ func testCommonNotations(t *testing.T, validOpts map[string]struct{}) {
 			notation: ":case",
 			expected: func(opt *option.Options) { opt.ExactCase = true },
 		},
+		{
+			notation: ":normalize snake acronym",
+			expected: func(opt *option.Options) {
+				snake, _ := option.NewNameNormalizer("snake")
+				acronym, _ := option.NewNameNormalizer("acronym")
+				opt.Normalizers = []option.NameNormalizer{snake, acronym}
+			},
+		},
+		{
+			notation: ":normalize:off",
+			expected: func(opt *option.Options) { opt.Normalizers = nil },
+		},
 		{
 			notation: ":getter:off",
 			expected: func(opt *option.Options) { opt.Getter = false },
@@ -77,13 +96,74 @@ func testCommonNotations(t *testing.T, validOpts map[string]struct{}) {
 			notation: ":stringer:off",
 			expected: func(opt *option.Options) { opt.Stringer = false },
 		},
+		{
+			notation: ":text",
+			expected: func(opt *option.Options) { opt.Text = true },
+		},
+		{
+			notation: ":text:off",
+			expected: func(opt *option.Options) { opt.Text = false },
+		},
 		{
 			notation: ":typecast",
 			expected: func(opt *option.Options) { opt.Typecast = true },
 		},
+		{
+			notation: ":typecast checked",
+			expected: func(opt *option.Options) {
+				opt.Typecast = true
+				opt.CheckedTypecast = true
+			},
+		},
 		{
 			notation: ":typecast:off",
-			expected: func(opt *option.Options) { opt.Typecast = false },
+			expected: func(opt *option.Options) {
+				opt.Typecast = false
+				opt.CheckedTypecast = false
+			},
+		},
+		{
+			notation: ":nilsafe",
+			expected: func(opt *option.Options) { opt.NilSafe = true },
+		},
+		{
+			notation: ":nilsafe:off",
+			expected: func(opt *option.Options) { opt.NilSafe = false },
+		},
+		{
+			notation: ":ptrcast error",
+			expected: func(opt *option.Options) {
+				opt.PtrCast = true
+				opt.NilPolicy = model.NilPolicyError
+			},
+		},
+		{
+			notation: ":ptrcast",
+			expected: func(opt *option.Options) { opt.PtrCast = true },
+		},
+		{
+			notation: ":ptrcast:off",
+			expected: func(opt *option.Options) { opt.PtrCast = false },
+		},
+		{
+			notation: ":merge",
+			expected: func(opt *option.Options) { opt.Merge = true },
+		},
+		{
+			notation: ":merge:off",
+			expected: func(opt *option.Options) { opt.Merge = false },
+		},
+		{
+			notation: ":builtin time,strconv",
+			expected: func(opt *option.Options) { opt.Builtins = []string{"time", "strconv"} },
+		},
+		{
+			notation: ":builtin uuid",
+			expected: func(opt *option.Options) { opt.Builtins = []string{"time", "strconv", "uuid"} },
+		},
+		{
+			notation: ":builtin:off",
+			expected: func(opt *option.Options) { opt.Builtins = nil },
 		},
 	}
 
@@ -122,6 +202,42 @@ func testMethodNotations(t *testing.T) {
 			notation:  ":map ID UserID",
 			validator: func(opt option.Options) bool { return len(opt.NameMapper) == 1 },
 		},
+		{
+			notation: ":conv:type formatTime time.Time string",
+			validator: func(opt option.Options) bool {
+				return len(opt.TypeConverters) == 1 && opt.TypeConverters[0].Dst() == "string"
+			},
+		},
+		{
+			notation: ":conv:with domain.NewPetStatusFromValue Status",
+			validator: func(opt option.Options) bool {
+				return len(opt.StructConverters) == 1 && opt.StructConverters[0].Converter() == "domain.NewPetStatusFromValue"
+			},
+		},
+		{
+			notation: `:fallback City "unknown"`,
+			validator: func(opt option.Options) bool {
+				return len(opt.Fallbacks) == 1 && opt.Fallbacks[0].Literal() == `"unknown"`
+			},
+		},
+		{
+			notation: ":flatten Address",
+			validator: func(opt option.Options) bool {
+				return len(opt.Flatten) == 1 && opt.Flatten[0].Prefix() == "Address"
+			},
+		},
+		{
+			notation: ":unflatten Location Geo",
+			validator: func(opt option.Options) bool {
+				return len(opt.Unflatten) == 1 && opt.Unflatten[0].Prefix() == "Geo"
+			},
+		},
+		{
+			notation: ":variant fromCard",
+			validator: func(opt option.Options) bool {
+				return len(opt.Variants) == 1 && opt.Variants[0].Converter() == "fromCard"
+			},
+		},
 	}
 
 	p, err := NewParser(
@@ -140,6 +256,120 @@ func testMethodNotations(t *testing.T) {
 	}
 }
 
+func TestMatchTagNeedsKey(t *testing.T) {
+	t.Parallel()
+
+	p, err := NewParser(
+		"../../tests/fixtures/usecase/getter/setup.go",
+		"../../tests/fixtures/usecase/getter/setup.gen.go",
+	)
+	require.Nil(t, err)
+
+	opts := option.NewOptions()
+	notations := []*ast.Comment{{Text: "// :match tag"}}
+	err = p.parseNotationInComments(notations, option.ValidOpsMethod, &opts)
+	assert.NotNil(t, err)
+}
+
+func TestTypecastNeedsKnownMode(t *testing.T) {
+	t.Parallel()
+
+	p, err := NewParser(
+		"../../tests/fixtures/usecase/getter/setup.go",
+		"../../tests/fixtures/usecase/getter/setup.gen.go",
+	)
+	require.Nil(t, err)
+
+	opts := option.NewOptions()
+	notations := []*ast.Comment{{Text: "// :typecast strict"}}
+	err = p.parseNotationInComments(notations, option.ValidOpsMethod, &opts)
+	assert.NotNil(t, err)
+}
+
+func TestBuiltinNeedsKnownGroup(t *testing.T) {
+	t.Parallel()
+
+	p, err := NewParser(
+		"../../tests/fixtures/usecase/getter/setup.go",
+		"../../tests/fixtures/usecase/getter/setup.gen.go",
+	)
+	require.Nil(t, err)
+
+	opts := option.NewOptions()
+	notations := []*ast.Comment{{Text: "// :builtin time,json"}}
+	err = p.parseNotationInComments(notations, option.ValidOpsMethod, &opts)
+	assert.NotNil(t, err)
+
+	notations = []*ast.Comment{{Text: "// :builtin"}}
+	err = p.parseNotationInComments(notations, option.ValidOpsMethod, &opts)
+	assert.NotNil(t, err)
+}
+
+func TestInvalidNotationDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	log := logger.New()
+	p, err := NewParser(
+		"../../tests/fixtures/usecase/getter/setup.go",
+		"../../tests/fixtures/usecase/getter/setup.gen.go",
+		Logger(log),
+	)
+	require.Nil(t, err)
+
+	opts := option.NewOptions()
+	notations := []*ast.Comment{{Text: "// :typcast"}, {Text: "// :skip Name"}, {Text: "// :nonsense"}}
+	err = p.parseNotationInComments(notations, option.ValidOpsIntf, &opts)
+	require.Nil(t, err)
+
+	diagnostics := log.Diagnostics()
+	require.Len(t, diagnostics, 3)
+	assert.Equal(t, logger.CodeUnknownNotation, diagnostics[0].Code)
+	assert.Equal(t, `did you mean ":typecast"?`, diagnostics[0].Fix)
+	assert.Equal(t, logger.CodeMisplacedNotation, diagnostics[1].Code)
+	assert.Equal(t, `":skip" is not available for an interface`, diagnostics[1].Message)
+	assert.Equal(t, logger.CodeUnknownNotation, diagnostics[2].Code)
+	assert.Equal(t, "", diagnostics[2].Fix)
+	for _, d := range diagnostics {
+		assert.Equal(t, logger.SeverityWarning, d.Severity)
+	}
+}
+
+func TestEnumNotation(t *testing.T) {
+	t.Parallel()
+
+	p, err := NewParser(
+		"../../tests/fixtures/usecase/enum/setup.go",
+		"../../tests/fixtures/usecase/enum/setup.gen.go",
+	)
+	require.Nil(t, err)
+
+	parse := func(notation string) (option.Options, error) {
+		opts := option.NewOptions()
+		// The types are evaluated in the file scope where the packages are imported.
+		notations := []*ast.Comment{{Slash: p.file.Name.Pos(), Text: "// " + notation}}
+		err := p.parseNotationInComments(notations, option.ValidOpsMethod, &opts)
+		return opts, err
+	}
+
+	opts, err := parse(":enum domain.Status model.Status Status*")
+	require.Nil(t, err)
+	require.Len(t, opts.EnumConverters, 1)
+	assert.True(t, opts.EnumConverters[0].Resolved())
+	assert.Len(t, opts.EnumConverters[0].Pairs(), 3)
+
+	_, err = parse(":enum domain.Status model.Status")
+	assert.NotNil(t, err, "unpaired constants")
+
+	_, err = parse(":enum domain.Status model.Status Status")
+	assert.NotNil(t, err, "invalid pattern")
+
+	_, err = parse(":enum domain.Status model.Status * * *")
+	assert.NotNil(t, err, "too many patterns")
+
+	_, err = parse(":enum domain.User model.User")
+	assert.NotNil(t, err, "not a named basic type")
+}
+
 func assertOptionsEquals(t *testing.T, a, b option.Options, msg string) {
 	t.Helper()
 	cmpOpts := []cmp.Option{
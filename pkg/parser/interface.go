@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"errors"
 	"go/types"
 	"unicode"
 
@@ -22,8 +23,11 @@ type intfEntry struct {
 // findConvergenEntries collects convergen interfaces from the setup file.
 // The target interface form either in the name of "Convergen" or having ":convergen" notation in its Doc comments.
 // For them, this function also parses notations in their doc comments.
+// It keeps an interface whose notations are invalid so that its methods are still checked,
+// and returns the entries along with the errors joined.
 func (p *Parser) findConvergenEntries() ([]*intfEntry, error) {
 	entries := make([]*intfEntry, 0)
+	var errs []error
 	scope := p.pkg.Types.Scope()
 	for _, name := range scope.Names() {
 		obj := scope.Lookup(name)
@@ -43,7 +47,7 @@ func (p *Parser) findConvergenEntries() ([]*intfEntry, error) {
 			continue
 		}
 
-		logger.Printf("%v: target interface found: %v", p.fset.Position(obj.Pos()), obj.Name())
+		p.logger.Printf("%v: target interface found: %v", p.fset.Position(obj.Pos()), obj.Name())
 
 		notations := util.ExtractMatchComments(docComment, reNotation)
 		if docComment != nil {
@@ -54,23 +58,23 @@ func (p *Parser) findConvergenEntries() ([]*intfEntry, error) {
 		opts := p.opts
 		err := p.parseNotationInComments(notations, option.ValidOpsIntf, &opts)
 		if err != nil {
-			return nil, err
+			errs = append(errs, err)
 		}
 
 		marker, _ := gonanoid.Nanoid()
 		entry := &intfEntry{
 			intf:   obj,
-			opts:   p.opts,
+			opts:   opts,
 			marker: marker,
 		}
 		entries = append(entries, entry)
 	}
 
 	if len(entries) == 0 {
-		return nil, logger.Errorf("%v: %v interface not found", p.fset.Position(p.file.Package), intfName)
+		return nil, p.logger.ErrorAt(p.fset.Position(p.file.Package), logger.CodeNotFound, "%v interface not found", intfName)
 	}
 
-	return entries, nil
+	return entries, errors.Join(errs...)
 }
 
 // isValidIdentifier checks if the given string is a valid identifier.
@@ -2,9 +2,7 @@ package parser
 
 import (
 	"errors"
-	"fmt"
 	"go/types"
-	"os"
 	"regexp"
 
 	"github.com/reedom/convergen/v8/pkg/builder/model"
@@ -17,43 +15,39 @@ var (
 	// reGoBuildGen is a regular expression that matches a notation that
 	// indicates the beginning of a convergen block.
 	reGoBuildGen = regexp.MustCompile(`\s*//\s*(go:(generate\b|build convergen\b)|\+build convergen)`)
-	// reConvergen is a regular expression that matches a notation that
-	// indicates the beginning of a convergen block.
-	errAbort = errors.New("abort")
 )
 
 // parseMethods parses all the methods in an interface type.
+// It continues with the rest of the methods if one fails, and returns the valid methods
+// along with the errors joined.
 func (p *Parser) parseMethods(intf *intfEntry) ([]*model.MethodEntry, error) {
 	iface := intf.intf.Type().Underlying().(*types.Interface)
 	mset := types.NewMethodSet(iface)
 	methods := make([]*model.MethodEntry, 0)
+	var errs []error
 	for i := 0; i < mset.Len(); i++ {
 		method, err := p.parseMethod(mset.At(i).Obj(), intf.opts)
 		if err != nil {
-			_, _ = fmt.Fprintln(os.Stderr, err.Error())
+			errs = append(errs, err)
 			continue
 		}
 		methods = append(methods, method)
 	}
-	if len(methods) < mset.Len() {
-		return nil, errAbort
-	}
-
-	return methods, nil
+	return methods, errors.Join(errs...)
 }
 
 // parseMethod parses a single method in an interface type.
 func (p *Parser) parseMethod(method types.Object, opts option.Options) (*model.MethodEntry, error) {
 	signature, ok := method.Type().(*types.Signature)
 	if !ok {
-		return nil, logger.Errorf(`%v: expected signature but %#v`, p.fset.Position(method.Pos()), method)
+		return nil, p.logger.ErrorAt(p.fset.Position(method.Pos()), logger.CodeInvalidMethod, `expected signature but %#v`, method)
 	}
 
 	if signature.Params().Len() == 0 {
-		return nil, logger.Errorf(`%v: method must have one or more arguments as copy source`, p.fset.Position(method.Pos()))
+		return nil, p.logger.ErrorAt(p.fset.Position(method.Pos()), logger.CodeInvalidMethod, `method must have one or more arguments as copy source`)
 	}
 	if signature.Results().Len() == 0 {
-		return nil, logger.Errorf(`%v: method must have one or more return values as copy destination`, p.fset.Position(method.Pos()))
+		return nil, p.logger.ErrorAt(p.fset.Position(method.Pos()), logger.CodeInvalidMethod, `method must have one or more return values as copy destination`)
 	}
 
 	docComment, cleanUp := util.GetDocCommentOn(p.file, method)
@@ -65,9 +59,19 @@ func (p *Parser) parseMethod(method types.Object, opts option.Options) (*model.M
 
 	cleanUp()
 
-	return &model.MethodEntry{
+	entry := &model.MethodEntry{
 		Method:     method,
 		Opts:       opts,
 		DocComment: docComment,
-	}, nil
+	}
+	if opts.CheckedTypecast && !entry.RetError() {
+		return nil, p.logger.Report(logger.Diagnostic{
+			Pos:      p.fset.Position(method.Pos()),
+			Severity: logger.SeverityError,
+			Code:     logger.CodeErrorReturnRequired,
+			Message:  `":typecast checked" requires the method to return an error`,
+			Fix:      logger.FixReturnError,
+		})
+	}
+	return entry, nil
 }
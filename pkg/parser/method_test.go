@@ -0,0 +1,18 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckedTypecastNeedsError(t *testing.T) {
+	p, err := NewParser(
+		"../../tests/fixtures/usecase/checkedcastnoerr/setup.go",
+		"../../tests/fixtures/usecase/checkedcastnoerr/setup.gen.go",
+	)
+	require.Nil(t, err)
+	_, err = p.Parse()
+	assert.NotNil(t, err)
+}
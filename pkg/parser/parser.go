@@ -2,18 +2,26 @@ package parser
 
 import (
 	"bytes"
+	"errors"
 	"go/ast"
+	"go/build/constraint"
 	"go/parser"
 	"go/printer"
 	"go/token"
+	"go/types"
 	"os"
+	"path"
+	"path/filepath"
 	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/reedom/convergen/v8/pkg/builder"
 	"github.com/reedom/convergen/v8/pkg/builder/model"
 	"github.com/reedom/convergen/v8/pkg/logger"
 	"github.com/reedom/convergen/v8/pkg/option"
 	"github.com/reedom/convergen/v8/pkg/util"
+	"golang.org/x/tools/go/ast/astutil"
 	"golang.org/x/tools/go/packages"
 )
 
@@ -27,116 +35,359 @@ type Parser struct {
 	pkg         *packages.Package // The package information for the parsed file.
 	opts        option.Options    // The options for the parser.
 	imports     util.ImportNames  // The import names used in the parsed file.
+	newImports  []string          // The import paths to add to the generated code.
 	intfEntries []*intfEntry      // The interface entries parsed from the file.
+	logger      *logger.Logger    // The logger to report the errors and warnings to.
 }
 
 // parserLoadMode is a packages.Load mode that loads types and syntax trees.
 const parserLoadMode = packages.NeedName | packages.NeedImports | packages.NeedDeps |
 	packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo
 
-// NewParser returns a new parser for convergen annotations.
-func NewParser(srcPath, dstPath string) (*Parser, error) {
-	fileSet := token.NewFileSet()
-	var fileSrc *ast.File
+// ParserOpt is a function that modifies the parser options.
+type ParserOpt func(*parserOption)
 
-	srcStat, err := os.Stat(srcPath)
-	if err != nil {
-		return nil, err
+// parserOption is a structure that holds the parser options.
+type parserOption struct {
+	dir     string            // The directory to resolve relative paths and package patterns in.
+	overlay map[string][]byte // The contents of the files to read in place of the files on disk.
+	logger  *logger.Logger    // The logger to report the errors and warnings to.
+}
+
+// Dir sets the directory to resolve relative paths and package patterns in.
+// By default, they are resolved in the current directory.
+func Dir(dir string) ParserOpt {
+	return func(opt *parserOption) {
+		opt.dir = dir
 	}
+}
 
-	dstStat, _ := os.Stat(dstPath)
-	var parseErr error
-	cfg := &packages.Config{
+// Overlay sets the contents of the files to read in place of the files on disk.
+// The keys are absolute file paths. A file in the overlay need not exist on disk.
+func Overlay(overlay map[string][]byte) ParserOpt {
+	return func(opt *parserOption) {
+		opt.overlay = overlay
+	}
+}
+
+// Logger sets the logger to report the errors and warnings to.
+// By default, the parser reports them to a new logger that writes nothing.
+func Logger(l *logger.Logger) ParserOpt {
+	return func(opt *parserOption) {
+		opt.logger = l
+	}
+}
+
+// newParserOption returns the parser options that the functions modify.
+func newParserOption(options []ParserOpt) parserOption {
+	opt := parserOption{}
+	for _, o := range options {
+		o(&opt)
+	}
+	if opt.logger == nil {
+		opt.logger = logger.New()
+	}
+	return opt
+}
+
+// absPath returns the absolute path of filePath relative to the directory of the options.
+func (o parserOption) absPath(filePath string) (string, error) {
+	if filePath == "" || filepath.IsAbs(filePath) {
+		return filePath, nil
+	}
+	return filepath.Abs(filepath.Join(o.dir, filePath))
+}
+
+// loadConfig returns the packages.Load configuration with the options.
+func (o parserOption) loadConfig(fileSet *token.FileSet,
+	parseFile func(fset *token.FileSet, filename string, src []byte) (*ast.File, error)) *packages.Config {
+	return &packages.Config{
 		Mode:       parserLoadMode,
 		BuildFlags: []string{"-tags", buildTag},
+		Dir:        o.dir,
 		Fset:       fileSet,
-		ParseFile: func(fset *token.FileSet, filename string, src []byte) (*ast.File, error) {
-			stat, err := os.Stat(filename)
-			if err != nil {
-				return nil, err
-			}
+		Overlay:    o.overlay,
+		ParseFile:  parseFile,
+	}
+}
 
-			// If previously generation target file exists, skip reading it.
-			if os.SameFile(stat, dstStat) {
-				return nil, nil
-			}
+// NewParser returns a new parser for convergen annotations.
+func NewParser(srcPath, dstPath string, options ...ParserOpt) (*Parser, error) {
+	opt := newParserOption(options)
+	srcPath, err := opt.absPath(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	dstPath, err = opt.absPath(dstPath)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := opt.overlay[srcPath]; !ok {
+		if _, err = os.Stat(srcPath); err != nil {
+			return nil, err
+		}
+	}
 
-			if !os.SameFile(stat, srcStat) {
-				return parser.ParseFile(fset, filename, src, 0)
-			}
+	fileSet := token.NewFileSet()
+	var fileSrc *ast.File
+	var parseErr error
+	cfg := opt.loadConfig(fileSet, func(fset *token.FileSet, filename string, src []byte) (*ast.File, error) {
+		// If previously generation target file exists, skip reading it.
+		if sameFile(filename, dstPath) {
+			return nil, nil
+		}
 
-			file, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
-			if err != nil {
-				parseErr = err
-				return nil, err
-			}
-			fileSrc = file
-			return file, nil
-		},
-	}
+		if !sameFile(filename, srcPath) {
+			return parser.ParseFile(fset, filename, src, 0)
+		}
+
+		file, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+		if err != nil {
+			parseErr = err
+			return nil, err
+		}
+		fileSrc = file
+		return file, nil
+	})
 	pkgs, err := packages.Load(cfg, "file="+srcPath)
 	if err != nil {
-		return nil, logger.Errorf("%v: failed to load type information: \n%w", srcPath, err)
+		return nil, opt.logger.ErrorAt(token.Position{Filename: srcPath}, logger.CodeLoadFailed, "failed to load type information: \n%w", err)
 	}
 	if len(pkgs) == 0 {
-		return nil, logger.Errorf("%v: failed to load package information", srcPath)
+		return nil, opt.logger.ErrorAt(token.Position{Filename: srcPath}, logger.CodeLoadFailed, "failed to load package information")
 	}
 
 	if fileSrc == nil && parseErr != nil {
-		return nil, logger.Errorf("%v: %v", srcPath, parseErr)
+		return nil, opt.logger.ErrorAt(token.Position{Filename: srcPath}, logger.CodeLoadFailed, "%v", parseErr)
 	}
+	return newParser(fileSet, pkgs[0], fileSrc, opt.logger), nil
+}
+
+// NewPackageParsers loads the packages that match the patterns, such as "./...", in one go,
+// and returns a parser for every setup file in them, i.e. a file that has the convergen build tag
+// and convergen interfaces. The parsers share the type information.
+// The code generated from a setup file previously is excluded from the packages.
+func NewPackageParsers(patterns []string, options ...ParserOpt) ([]*Parser, error) {
+	opt := newParserOption(options)
+	fileSet := token.NewFileSet()
+	cfg := opt.loadConfig(fileSet, func(fset *token.FileSet, filename string, src []byte) (*ast.File, error) {
+		if isGeneratedFile(filename, opt.overlay) {
+			return nil, nil
+		}
+		return parser.ParseFile(fset, filename, src, parser.ParseComments)
+	})
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, opt.logger.ErrorAt(token.Position{}, logger.CodeLoadFailed,
+			"%v: failed to load type information: \n%w", strings.Join(patterns, " "), err)
+	}
+
+	var parsers []*Parser
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Syntax {
+			if file == nil || !hasBuildTag(file) {
+				continue
+			}
+			p := newParser(fileSet, pkg, file, opt.logger)
+			if p.hasConvergenInterface() {
+				parsers = append(parsers, p)
+			}
+		}
+	}
+	sort.Slice(parsers, func(i, j int) bool {
+		return parsers[i].srcPath < parsers[j].srcPath
+	})
+	return parsers, nil
+}
+
+// newParser returns a new parser for the setup file in the package.
+func newParser(fileSet *token.FileSet, pkg *packages.Package, file *ast.File, logger *logger.Logger) *Parser {
 	return &Parser{
-		srcPath: fileSet.Position(fileSrc.Pos()).Filename,
+		srcPath: fileSet.Position(file.Pos()).Filename,
 		fset:    fileSet,
-		file:    fileSrc,
-		pkg:     pkgs[0],
+		file:    file,
+		pkg:     pkg,
 		opts:    option.NewOptions(),
-		imports: util.NewImportNames(fileSrc.Imports),
-	}, nil
+		imports: util.NewImportNames(file.Imports),
+		logger:  logger,
+	}
 }
 
-// Parse parses convergen annotations in the source code.
-func (p *Parser) Parse() ([]*model.MethodsInfo, error) {
-	entries, err := p.findConvergenEntries()
+// sameFile returns true if the paths point to the same file.
+// A file that exists only in the overlay is the same file as its path only.
+func sameFile(a, b string) bool {
+	if a == b {
+		return true
+	}
+	aStat, err := os.Stat(a)
 	if err != nil {
-		return nil, err
+		return false
+	}
+	bStat, err := os.Stat(b)
+	if err != nil {
+		return false
 	}
+	return os.SameFile(aStat, bStat)
+}
 
-	var allMethods []*model.MethodEntry
+// hasBuildTag returns true if the file is built only with the convergen build tag.
+func hasBuildTag(file *ast.File) bool {
+	for _, cg := range file.Comments {
+		if file.Package < cg.Pos() {
+			break
+		}
+		for _, c := range cg.List {
+			if !constraint.IsGoBuild(c.Text) {
+				continue
+			}
+			expr, err := constraint.Parse(c.Text)
+			if err != nil {
+				return false
+			}
+			return expr.Eval(func(tag string) bool { return tag == buildTag }) &&
+				!expr.Eval(func(string) bool { return false })
+		}
+	}
+	return false
+}
 
-	var list []*model.MethodsInfo
-	for _, entry := range entries {
-		methods, err := p.parseMethods(entry)
-		if err != nil {
-			return nil, err
+// isGeneratedFile returns true if the file is "<name>.gen.go" next to the setup file "<name>.go".
+// The setup file is read from the overlay if it is there.
+func isGeneratedFile(filename string, overlay map[string][]byte) bool {
+	const ext = ".gen.go"
+	if !strings.HasSuffix(filename, ext) {
+		return false
+	}
+	setupPath := strings.TrimSuffix(filename, ext) + ".go"
+	var src any
+	if content, ok := overlay[setupPath]; ok {
+		src = content
+	}
+	setup, err := parser.ParseFile(token.NewFileSet(), setupPath, src, parser.PackageClauseOnly|parser.ParseComments)
+	if err != nil {
+		return false
+	}
+	return hasBuildTag(setup)
+}
+
+// SrcPath returns the path of the setup file.
+func (p *Parser) SrcPath() string {
+	return p.srcPath
+}
+
+// hasConvergenInterface returns true if the setup file defines a convergen interface.
+// It recognizes the interfaces as findConvergenEntries does, without parsing their notations.
+func (p *Parser) hasConvergenInterface() bool {
+	scope := p.pkg.Types.Scope()
+	for _, name := range scope.Names() {
+		obj := scope.Lookup(name)
+		if _, ok := obj.Type().Underlying().(*types.Interface); !ok {
+			continue
 		}
-		info := &model.MethodsInfo{
-			Marker:  entry.marker,
-			Methods: methods,
+		if p.srcPath != p.fset.Position(obj.Pos()).Filename {
+			continue
 		}
-		list = append(list, info)
+		if obj.Name() == intfName {
+			return true
+		}
+		docComment, _ := util.GetDocCommentOn(p.file, obj)
+		if util.MatchComments(docComment, reConvergen) {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse parses convergen annotations in the source code.
+// It parses every interface and method even if some of them are invalid, so that all the errors
+// are reported at once. In that case, it returns the methods that are valid along with the errors joined.
+func (p *Parser) Parse() ([]*model.MethodsInfo, error) {
+	entries, err := p.findConvergenEntries()
+	errs := []error{err}
+
+	// The conversions that need no notation, such as taking the address of a converted value,
+	// may call stdconv, too.
+	p.addImport(option.StdconvPkgPath)
+
+	var allMethods []*model.MethodEntry
+	entryMethods := make([][]*model.MethodEntry, len(entries))
+	for i, entry := range entries {
+		methods, err := p.parseMethods(entry)
+		errs = append(errs, err)
+		entryMethods[i] = methods
 		allMethods = append(allMethods, methods...)
 	}
 
 	// Resolve converters.
 	// Some converters may refer to-be-generated functions that go/types doesn't contain
 	// so that they are needed to be resolved manually.
+	// The converters of an interface are shared by its methods, so that each one is resolved once.
+	resolved := make(map[any]error)
+	resolve := func(conv any, fn func() error) error {
+		if err, ok := resolved[conv]; ok {
+			return err
+		}
+		err := fn()
+		resolved[conv] = err
+		return err
+	}
+	invalid := make(map[*model.MethodEntry]bool)
 	for _, method := range allMethods {
+		var methodErrs []error
 		for _, conv := range method.Opts.Converters {
-			err = p.resolveConverters(allMethods, conv)
-			if err != nil {
-				return nil, err
+			methodErrs = append(methodErrs, resolve(conv, func() error {
+				return p.resolveConverters(allMethods, conv)
+			}))
+		}
+		for _, conv := range method.Opts.TypeConverters {
+			methodErrs = append(methodErrs, resolve(conv, func() error {
+				return p.resolveTypeConverter(allMethods, conv)
+			}))
+		}
+		for _, conv := range method.Opts.Variants {
+			methodErrs = append(methodErrs, resolve(conv, func() error {
+				return p.resolveVariantConverter(allMethods, conv)
+			}))
+		}
+		if err := errors.Join(methodErrs...); err != nil {
+			errs = append(errs, err)
+			invalid[method] = true
+		}
+	}
+
+	var list []*model.MethodsInfo
+	for i, entry := range entries {
+		methods := make([]*model.MethodEntry, 0, len(entryMethods[i]))
+		for _, method := range entryMethods[i] {
+			if !invalid[method] {
+				methods = append(methods, method)
 			}
 		}
+		info := &model.MethodsInfo{
+			Marker:  entry.marker,
+			Methods: methods,
+		}
+		list = append(list, info)
 	}
 
 	p.intfEntries = entries
-	return list, nil
+	return list, errors.Join(errs...)
 }
 
 // CreateBuilder creates a new function builder.
 func (p *Parser) CreateBuilder() *builder.FunctionBuilder {
-	return builder.NewFunctionBuilder(p.file, p.fset, p.pkg, p.imports)
+	return builder.NewFunctionBuilder(p.file, p.fset, p.pkg, p.imports, p.logger)
+}
+
+// addImport adds an import of pkgPath to the generated code unless the source file already has one,
+// so that the generated code can refer to the package. An unused import is removed
+// when the generated code is formatted.
+func (p *Parser) addImport(pkgPath string) {
+	if _, ok := p.imports.LookupName(pkgPath); ok {
+		return
+	}
+	p.imports[pkgPath] = path.Base(pkgPath)
+	p.newImports = append(p.newImports, pkgPath)
 }
 
 // GenerateBaseCode generates the base code without convergen annotations.
@@ -179,6 +430,20 @@ func (p *Parser) GenerateBaseCode() (code string, err error) {
 		util.InsertComment(p.file, entry.marker, maxPos)
 	}
 
+	if 0 < len(p.newImports) {
+		// astutil.AddImport doesn't expect comment groups that have been emptied by the notation removal.
+		comments := p.file.Comments[:0]
+		for _, cg := range p.file.Comments {
+			if 0 < len(cg.List) {
+				comments = append(comments, cg)
+			}
+		}
+		p.file.Comments = comments
+		for _, pkgPath := range p.newImports {
+			astutil.AddImport(p.fset, p.file, pkgPath)
+		}
+	}
+
 	var buf bytes.Buffer
 	err = printer.Fprint(&buf, p.fset, p.file)
 	if err != nil {
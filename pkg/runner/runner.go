@@ -1,71 +1,101 @@
 package runner
 
 import (
+	"errors"
+	"fmt"
+	"go/token"
 	"os"
 
 	"github.com/reedom/convergen/v8/pkg/config"
+	"github.com/reedom/convergen/v8/pkg/convergen"
 	"github.com/reedom/convergen/v8/pkg/generator"
-	"github.com/reedom/convergen/v8/pkg/generator/model"
 	"github.com/reedom/convergen/v8/pkg/logger"
-	"github.com/reedom/convergen/v8/pkg/parser"
 )
 
+// ErrReported is returned by Run if it fails after reporting the problems as diagnostics.
+var ErrReported = errors.New("convergen failed")
+
 // Run runs the convergen code generator using the provided configuration.
-// If a log file path is specified in the configuration, the logger will output to that file.
-// It creates a parser instance from the input and output paths in the configuration,
-// and then generates a list of methods from the parsed source code. Using a function builder,
-// the generator creates a block of functions for each set of methods and combines them with
-// the parsed base code. Finally, it generates the output files using the generated code and
-// the provided configuration options.
+// If a log file path is specified in the configuration, the log messages are written to that file.
+// If the configuration has package patterns, it processes every setup file in the packages instead.
+// It generates the code in-process by convergen.Generate, and then writes the generated code
+// to the output files according to the configuration options.
+// In check mode, it writes nothing but prints the differences from the existing files, and reports
+// every file that is out of date.
+// Finally, it writes the diagnostics to stderr in the configured format, and returns ErrReported
+// if any of them is an error.
 func Run(conf config.Config) error {
+	req := convergen.Request{
+		Input:    conf.Input,
+		Output:   conf.Output,
+		Patterns: conf.Patterns,
+	}
 	if conf.Log != "" {
 		f, err := os.OpenFile(conf.Log, os.O_RDWR|os.O_TRUNC|os.O_CREATE, 0644)
 		if err != nil {
 			return err
 		}
-		logger.SetupLogger(logger.Enable(), logger.Output(f))
+		defer f.Close()
+		req.Log = f
 	}
 
-	p, err := parser.NewParser(conf.Input, conf.Output)
-	if err != nil {
-		return err
+	result, err := convergen.Generate(req)
+	diagnostics := result.Diagnostics
+	failed := err != nil
+	for _, file := range result.Files {
+		if d := output(file, conf); d != nil {
+			diagnostics = append(diagnostics, *d)
+			failed = true
+		}
 	}
 
-	methods, err := p.Parse()
-	if err != nil {
+	if err := logger.WriteDiagnostics(os.Stderr, conf.DiagFormat, diagnostics); err != nil {
 		return err
 	}
+	if failed {
+		return ErrReported
+	}
+	return nil
+}
 
-	builder := p.CreateBuilder()
-
-	var funcBlocks []model.FunctionsBlock
-	for _, info := range methods {
-		functions, err := builder.CreateFunctions(info.Methods)
+// output writes the generated code to its output path.
+// With conf.Check, it prints the differences between the code and the file at the path instead.
+// It returns a diagnostic if the file is out of date or cannot be written, or nil.
+func output(file convergen.File, conf config.Config) *logger.Diagnostic {
+	if conf.Check {
+		diff, err := generator.Diff(file.Output, file.Code)
 		if err != nil {
-			return err
+			return fileDiagnostic(file, logger.CodeGenerateFailed, err.Error(), "")
 		}
-		block := model.FunctionsBlock{
-			Marker:    info.Marker,
-			Functions: functions,
+		if diff == "" {
+			return nil
 		}
-		funcBlocks = append(funcBlocks, block)
+		fmt.Print(diff)
+		return fileDiagnostic(file, logger.CodeOutOfDate, "the generated file is out of date",
+			fmt.Sprintf("run convergen for %v", file.Input))
 	}
 
-	baseCode, err := p.GenerateBaseCode()
-	if err != nil {
-		return err
+	if conf.Prints {
+		fmt.Println(string(file.Code))
 	}
-
-	code := model.Code{
-		BaseCode:       baseCode,
-		FunctionBlocks: funcBlocks,
+	if conf.DryRun {
+		return nil
 	}
 
-	g := generator.NewGenerator(code)
-	_, err = g.Generate(conf.Output, conf.Prints, conf.DryRun)
+	err := os.WriteFile(file.Output, file.Code, 0644)
 	if err != nil {
-		return err
+		return fileDiagnostic(file, logger.CodeGenerateFailed, fmt.Sprintf("error on writing to the file.\n%v", err), "")
 	}
-
 	return nil
 }
+
+// fileDiagnostic returns an error diagnostic about the output file.
+func fileDiagnostic(file convergen.File, code logger.Code, msg, fix string) *logger.Diagnostic {
+	return &logger.Diagnostic{
+		Pos:      token.Position{Filename: file.Output},
+		Severity: logger.SeverityError,
+		Code:     code,
+		Message:  msg,
+		Fix:      fix,
+	}
+}
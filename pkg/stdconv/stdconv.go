@@ -0,0 +1,147 @@
+// Package stdconv provides the well-known conversions that the code generated with
+// the ":builtin" notation calls, and the helpers that the other generated code calls.
+package stdconv
+
+import (
+	"encoding"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+	"unsafe"
+)
+
+// UnixMilli returns t as a Unix time in milliseconds.
+func UnixMilli(t time.Time) int64 {
+	return t.UnixMilli()
+}
+
+// FormatInt64 returns the decimal string of v.
+func FormatInt64(v int64) string {
+	return strconv.FormatInt(v, 10)
+}
+
+// ParseInt64 parses s as a decimal int64.
+func ParseInt64(s string) (int64, error) {
+	return strconv.ParseInt(s, 10, 64)
+}
+
+// FormatFloat64 returns the shortest string that represents v exactly.
+func FormatFloat64(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// ParseFloat64 parses s as a float64.
+func ParseFloat64(s string) (float64, error) {
+	return strconv.ParseFloat(s, 64)
+}
+
+// FormatUUID returns u in the canonical form, e.g. "6ba7b810-9dad-11d1-80b4-00c04fd430c8".
+func FormatUUID(u [16]byte) string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
+
+// ParseUUID parses s in the canonical form, or in 32 hex digits without hyphens.
+func ParseUUID(s string) (u [16]byte, err error) {
+	var digits string
+	switch len(s) {
+	case 36:
+		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+			return u, fmt.Errorf("invalid UUID %q", s)
+		}
+		digits = s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+	case 32:
+		digits = s
+	default:
+		return u, fmt.Errorf("invalid UUID %q", s)
+	}
+	if _, err = hex.Decode(u[:], []byte(digits)); err != nil {
+		return u, fmt.Errorf("invalid UUID %q", s)
+	}
+	return u, nil
+}
+
+// MarshalText returns the text form of v by its MarshalText method.
+func MarshalText(v encoding.TextMarshaler) (string, error) {
+	text, err := v.MarshalText()
+	if err != nil {
+		return "", err
+	}
+	return string(text), nil
+}
+
+// UnmarshalText returns a value of T that the UnmarshalText method of *T decodes s into.
+func UnmarshalText[T any, PT interface {
+	*T
+	encoding.TextUnmarshaler
+}](s string) (T, error) {
+	var v T
+	err := PT(&v).UnmarshalText([]byte(s))
+	return v, err
+}
+
+// Integer is a constraint that permits any integer type.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// CastInt converts v into T, or returns an error if T cannot represent v.
+// name describes v in the error, such as "src.Count".
+func CastInt[T, S Integer](v S, name string) (T, error) {
+	t := T(v)
+	if S(t) != v || (t < 0) != (v < 0) {
+		return t, fmt.Errorf("%v: %v overflows %T", name, v, t)
+	}
+	return t, nil
+}
+
+// Float is a constraint that permits any floating-point type.
+type Float interface {
+	~float32 | ~float64
+}
+
+// CastFloat converts v into T, or returns an error if a finite v overflows T to an infinity.
+// Precision may be lost as a typecast does. name describes v in the error, such as "src.Ratio".
+func CastFloat[T, S Float](v S, name string) (T, error) {
+	t := T(v)
+	if math.IsInf(float64(t), 0) && !math.IsInf(float64(v), 0) {
+		return t, fmt.Errorf("%v: %v overflows %T", name, v, t)
+	}
+	return t, nil
+}
+
+// CastFloatToInt converts v into T truncating its fraction as a typecast does, or returns an error
+// if v is NaN, an infinity, or T cannot represent the integer part of v.
+// name describes v in the error, such as "src.Ratio".
+func CastFloatToInt[T Integer, S Float](v S, name string) (T, error) {
+	var zero T
+	bits := int(unsafe.Sizeof(zero)) * 8
+	minValue, maxValue := 0.0, math.Ldexp(1, bits)
+	if ^zero < 0 {
+		// Signed.
+		minValue, maxValue = -math.Ldexp(1, bits-1), math.Ldexp(1, bits-1)
+	}
+
+	f := math.Trunc(float64(v))
+	if math.IsNaN(f) || f < minValue || maxValue <= f {
+		return zero, fmt.Errorf("%v: %v overflows %T", name, v, zero)
+	}
+	return T(f), nil
+}
+
+// Ptr returns a pointer to a copy of v, so that the pointer does not share v with its source.
+func Ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,149 @@
+package stdconv_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/reedom/convergen/v8/pkg/stdconv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnixMilli(t *testing.T) {
+	tm := time.Date(2023, 4, 5, 6, 7, 8, 9_000_000, time.UTC)
+	assert.Equal(t, tm.UnixMilli(), stdconv.UnixMilli(tm))
+	assert.True(t, tm.Equal(time.UnixMilli(stdconv.UnixMilli(tm))))
+}
+
+func TestInt64(t *testing.T) {
+	assert.Equal(t, "-42", stdconv.FormatInt64(-42))
+
+	v, err := stdconv.ParseInt64("9223372036854775807")
+	require.Nil(t, err)
+	assert.Equal(t, int64(9223372036854775807), v)
+
+	_, err = stdconv.ParseInt64("1.5")
+	assert.NotNil(t, err)
+}
+
+func TestFloat64(t *testing.T) {
+	assert.Equal(t, "0.1", stdconv.FormatFloat64(0.1))
+	assert.Equal(t, "1e+21", stdconv.FormatFloat64(1e21))
+
+	v, err := stdconv.ParseFloat64("2.5")
+	require.Nil(t, err)
+	assert.Equal(t, 2.5, v)
+
+	_, err = stdconv.ParseFloat64("x")
+	assert.NotNil(t, err)
+}
+
+func TestUUID(t *testing.T) {
+	u := [16]byte{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+	s := "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	assert.Equal(t, s, stdconv.FormatUUID(u))
+
+	cases := []struct {
+		input string
+		err   bool
+	}{
+		{input: s},
+		{input: "6BA7B8109DAD11D180B400C04FD430C8"},
+		{input: "6ba7b810-9dad-11d1-80b4_00c04fd430c8", err: true},
+		{input: "6ba7b810-9dad-11d1-80b4-00c04fd430cx", err: true},
+		{input: "6ba7b810", err: true},
+	}
+	for _, tt := range cases {
+		actual, err := stdconv.ParseUUID(tt.input)
+		if tt.err {
+			assert.NotNil(t, err, tt.input)
+			continue
+		}
+		require.Nil(t, err, tt.input)
+		assert.Equal(t, u, actual, tt.input)
+	}
+}
+
+func TestText(t *testing.T) {
+	tm := time.Date(2023, 4, 5, 6, 7, 8, 0, time.UTC)
+	s, err := stdconv.MarshalText(tm)
+	require.Nil(t, err)
+	assert.Equal(t, "2023-04-05T06:07:08Z", s)
+
+	actual, err := stdconv.UnmarshalText[time.Time](s)
+	require.Nil(t, err)
+	assert.True(t, tm.Equal(actual))
+
+	_, err = stdconv.UnmarshalText[time.Time]("yesterday")
+	assert.NotNil(t, err)
+}
+
+func TestCastInt(t *testing.T) {
+	type Count int16
+
+	v, err := stdconv.CastInt[int32](int64(-2147483648), "v")
+	require.Nil(t, err)
+	assert.Equal(t, int32(-2147483648), v)
+
+	_, err = stdconv.CastInt[int32](int64(2147483648), "src.Count")
+	assert.EqualError(t, err, "src.Count: 2147483648 overflows int32")
+
+	_, err = stdconv.CastInt[uint8](-1, "v")
+	assert.NotNil(t, err, "negative into unsigned")
+
+	_, err = stdconv.CastInt[int8](uint8(128), "v")
+	assert.NotNil(t, err, "unsigned into signed")
+
+	_, err = stdconv.CastInt[uint64](int64(-1), "v")
+	assert.NotNil(t, err, "same size")
+
+	c, err := stdconv.CastInt[Count](uint(300), "v")
+	require.Nil(t, err)
+	assert.Equal(t, Count(300), c)
+
+	_, err = stdconv.CastInt[Count](70000, "src.Count")
+	assert.EqualError(t, err, "src.Count: 70000 overflows stdconv_test.Count")
+}
+
+func TestCastFloat(t *testing.T) {
+	v, err := stdconv.CastFloat[float32](1.5, "v")
+	require.Nil(t, err)
+	assert.Equal(t, float32(1.5), v)
+
+	_, err = stdconv.CastFloat[float32](1e39, "src.Ratio")
+	assert.EqualError(t, err, "src.Ratio: 1e+39 overflows float32")
+
+	v, err = stdconv.CastFloat[float32](math.Inf(-1), "v")
+	require.Nil(t, err, "an infinity stays as it is")
+	assert.True(t, math.IsInf(float64(v), -1))
+}
+
+func TestCastFloatToInt(t *testing.T) {
+	v, err := stdconv.CastFloatToInt[int8](-128.9, "v")
+	require.Nil(t, err)
+	assert.Equal(t, int8(-128), v)
+
+	_, err = stdconv.CastFloatToInt[int8](128.0, "src.Score")
+	assert.EqualError(t, err, "src.Score: 128 overflows int8")
+
+	u, err := stdconv.CastFloatToInt[uint8](-0.5, "v")
+	require.Nil(t, err, "the integer part is zero")
+	assert.Equal(t, uint8(0), u)
+
+	_, err = stdconv.CastFloatToInt[uint8](-1.0, "v")
+	assert.NotNil(t, err, "negative into unsigned")
+
+	_, err = stdconv.CastFloatToInt[int64](float32(9.3e18), "v")
+	assert.NotNil(t, err, "over the maximum of int64")
+
+	_, err = stdconv.CastFloatToInt[int](math.NaN(), "v")
+	assert.NotNil(t, err, "NaN")
+}
+
+func TestPtr(t *testing.T) {
+	v := 42
+	p := stdconv.Ptr(v)
+	assert.Equal(t, 42, *p)
+	assert.NotSame(t, &v, p)
+}
@@ -74,16 +74,39 @@ func (i ImportNames) TypeName(t types.Type) string {
 		return "*" + i.TypeName(typ.Elem())
 	case *types.Basic:
 		return typ.Name()
+	case *types.Slice:
+		return "[]" + i.TypeName(typ.Elem())
+	case *types.Map:
+		return fmt.Sprintf("map[%v]%v", i.TypeName(typ.Key()), i.TypeName(typ.Elem()))
+	case *types.Array:
+		return fmt.Sprintf("[%d]%v", typ.Len(), i.TypeName(typ.Elem()))
 	case *types.Named:
-		if pkgName, ok := i[typ.Obj().Pkg().Path()]; ok {
-			return fmt.Sprintf("%v.%v", pkgName, typ.Obj().Name())
-		}
-		return typ.Obj().Name()
+		return i.qualifiedName(typ.Obj(), typ.TypeArgs())
+	case *types.Alias:
+		return i.qualifiedName(typ.Obj(), typ.TypeArgs())
 	default:
 		return t.String()
 	}
 }
 
+// qualifiedName returns the name of a named type or an alias with its package name and type arguments.
+func (i ImportNames) qualifiedName(obj *types.TypeName, args *types.TypeList) string {
+	name := obj.Name()
+	if pkg := obj.Pkg(); pkg != nil {
+		if pkgName, ok := i[pkg.Path()]; ok {
+			name = fmt.Sprintf("%v.%v", pkgName, name)
+		}
+	}
+	if args != nil && 0 < args.Len() {
+		names := make([]string, args.Len())
+		for j := 0; j < args.Len(); j++ {
+			names[j] = i.TypeName(args.At(j))
+		}
+		name = fmt.Sprintf("%v[%v]", name, strings.Join(names, ", "))
+	}
+	return name
+}
+
 // IsExternal returns true if the given type is defined in a different package than
 // the conversion setup file.
 func (i ImportNames) IsExternal(t types.Type) bool {
@@ -55,8 +55,20 @@ func TestImportNames_TypeName(t *testing.T) {
 
 		type MyInt int
 
+		type Box[T any] struct {
+			V T
+		}
+
 		var now = time.Now()
 
+		var box Box[time.Time]
+
+		type TimeBox = Box[time.Time]
+
+		var aliasBox TimeBox
+
+		var times [3]time.Time
+
 		func main() {
 			fmt.Println(now)
 			var x MyInt
@@ -85,6 +97,17 @@ func TestImportNames_TypeName(t *testing.T) {
 	assert.Equal(t, "time.Time", imports.TypeName(namedType))
 	assert.True(t, imports.IsExternal(namedType))
 
+	// Test TypeName with composite types.
+	assert.Equal(t, "[]time.Time", imports.TypeName(types.NewSlice(namedType)))
+	assert.Equal(t, "map[string]*time.Time", imports.TypeName(types.NewMap(types.Typ[types.String], types.NewPointer(namedType))))
+
+	// Test TypeName with instantiated generic types.
+	assert.Equal(t, "Box[time.Time]", imports.TypeName(pkg.Scope().Lookup("box").Type()))
+
+	// Test TypeName with aliases and arrays.
+	assert.Equal(t, "TimeBox", imports.TypeName(pkg.Scope().Lookup("aliasBox").Type()))
+	assert.Equal(t, "[3]time.Time", imports.TypeName(pkg.Scope().Lookup("times").Type()))
+
 	path, ok := imports.LookupName("time")
 	assert.True(t, ok)
 	assert.NotEmpty(t, path)
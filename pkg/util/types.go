@@ -2,8 +2,10 @@ package util
 
 import (
 	"go/ast"
+	"go/token"
 	"go/types"
 	"path"
+	"reflect"
 	"strings"
 
 	"golang.org/x/tools/go/ast/astutil"
@@ -39,6 +41,12 @@ func IsSliceType(t types.Type) bool {
 	return ok
 }
 
+// IsArrayType returns true if the given type is an array type or a named type of an array.
+func IsArrayType(t types.Type) bool {
+	_, ok := t.Underlying().(*types.Array)
+	return ok
+}
+
 // IsBasicType returns true if the given type is a basic type.
 func IsBasicType(t types.Type) bool {
 	_, ok := t.(*types.Basic)
@@ -53,7 +61,7 @@ func IsStructType(t types.Type) bool {
 
 // IsNamedType returns true if the given type is a named type.
 func IsNamedType(t types.Type) bool {
-	_, ok := t.(*types.Named)
+	_, ok := types.Unalias(t).(*types.Named)
 	return ok
 }
 
@@ -65,30 +73,44 @@ func IsFunc(obj types.Object) bool {
 
 // IsPtr returns true if the given type is a pointer type.
 func IsPtr(t types.Type) bool {
-	_, ok := t.(*types.Pointer)
+	_, ok := types.Unalias(t).(*types.Pointer)
 	return ok
 }
 
+// IsTypedNilable returns true if a value of the given type can be nil but is not an interface,
+// so that an interface that holds the nil value is not nil itself.
+func IsTypedNilable(t types.Type) bool {
+	switch t.Underlying().(type) {
+	case *types.Pointer, *types.Slice, *types.Map, *types.Chan, *types.Signature:
+		return true
+	}
+	return false
+}
+
 // DerefPtr dereferences a *Pointer type and returns its base type.
+// An alias is resolved into the type it refers, on both the pointer and the base type.
 func DerefPtr(typ types.Type) types.Type {
+	typ = types.Unalias(typ)
 	if ptr, ok := typ.(*types.Pointer); ok {
-		return ptr.Elem()
+		return types.Unalias(ptr.Elem())
 	}
 	return typ
 }
 
 // Deref dereferences a type if it is a *Pointer type and returns its base type and true.
 // Otherwise, it returns (typ, false).
+// An alias is resolved into the type it refers, as DerefPtr does.
 func Deref(typ types.Type) (types.Type, bool) {
+	typ = types.Unalias(typ)
 	if ptr, ok := typ.(*types.Pointer); ok {
-		return ptr.Elem(), true
+		return types.Unalias(ptr.Elem()), true
 	}
 	return typ, false
 }
 
 // PkgOf returns the package of the given type.
 func PkgOf(t types.Type) *types.Package {
-	switch typ := t.(type) {
+	switch typ := types.Unalias(t).(type) {
 	case *types.Pointer:
 		return PkgOf(typ.Elem())
 	case *types.Named:
@@ -106,6 +128,29 @@ func SliceElement(t types.Type) types.Type {
 	return nil
 }
 
+// ArrayElement returns the type of the element and the length of an array type, or nil and 0.
+// A named type that has an array type as its underlying type, such as a UUID, is an array type, too.
+func ArrayElement(t types.Type) (elem types.Type, length int64) {
+	if array, ok := t.Underlying().(*types.Array); ok {
+		return array.Elem(), array.Len()
+	}
+	return nil, 0
+}
+
+// IsMapType returns true if the given type is a map type.
+func IsMapType(t types.Type) bool {
+	_, ok := t.Underlying().(*types.Map)
+	return ok
+}
+
+// MapKeyElem returns the types of the key and the element in a map type.
+func MapKeyElem(t types.Type) (key, elem types.Type) {
+	if m, ok := t.Underlying().(*types.Map); ok {
+		return m.Key(), m.Elem()
+	}
+	return nil, nil
+}
+
 // GetDocCommentOn retrieves doc comments that relate to nodes.
 func GetDocCommentOn(file *ast.File, obj types.Object) (cg *ast.CommentGroup, cleanUp func()) {
 	nodes, _ := ToAstNode(file, obj)
@@ -259,6 +304,84 @@ func IterateFields(t types.Type, cb func(*types.Var) (done bool)) {
 	}
 }
 
+// ZeroValueExpr returns an expression of the zero value of the given type.
+// typeName is the type expression of t that is used for a composite literal, e.g. "model.User".
+func ZeroValueExpr(t types.Type, typeName string) string {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			return "false"
+		case u.Info()&types.IsString != 0:
+			return `""`
+		case u.Info()&types.IsNumeric != 0:
+			return "0"
+		}
+		return "nil"
+	case *types.Struct, *types.Array:
+		return typeName + "{}"
+	default:
+		return "nil"
+	}
+}
+
+// NullableValueField returns the value field of a nullable wrapper type such as sql.NullString
+// and sql.Null[T], i.e. a named struct that consists of a "Valid bool" field and one other field.
+// It returns nil if t is not in the shape.
+func NullableValueField(t types.Type) *types.Var {
+	if _, ok := types.Unalias(t).(*types.Named); !ok {
+		return nil
+	}
+	strct, ok := t.Underlying().(*types.Struct)
+	if !ok || strct.NumFields() != 2 {
+		return nil
+	}
+
+	var value *types.Var
+	valid := false
+	for i := 0; i < strct.NumFields(); i++ {
+		field := strct.Field(i)
+		if !field.Exported() || field.Embedded() {
+			return nil
+		}
+		if field.Name() == "Valid" && types.Identical(field.Type(), types.Typ[types.Bool]) {
+			valid = true
+		} else {
+			value = field
+		}
+	}
+	if !valid || value == nil {
+		return nil
+	}
+	return value
+}
+
+// LookupTagValue returns the name part of the struct tag associated with key of the given field,
+// e.g. "user_id" for `json:"user_id,omitempty"`.
+// It returns false if the field does not have the tag, or the name part is empty or "-".
+func LookupTagValue(t types.Type, field *types.Var, key string) (string, bool) {
+	strct, ok := DerefPtr(t).Underlying().(*types.Struct)
+	if !ok {
+		return "", false
+	}
+
+	for i := 0; i < strct.NumFields(); i++ {
+		if strct.Field(i) != field {
+			continue
+		}
+		value, ok := reflect.StructTag(strct.Tag(i)).Lookup(key)
+		if !ok {
+			return "", false
+		}
+		name, _, _ := strings.Cut(value, ",")
+		if name == "" || name == "-" {
+			return "", false
+		}
+		return name, true
+	}
+	return "", false
+}
+
 // GetMethodReturnTypes returns the return types of the given method.
 func GetMethodReturnTypes(m *types.Func) (*types.Tuple, bool) {
 	sig := m.Type().(*types.Signature)
@@ -324,3 +447,147 @@ func CompliesStringer(src types.Type) bool {
 		sig.Results().Len() == 1 &&
 		sig.Results().At(0).Type().String() == "string"
 }
+
+var (
+	// textMarshaler is the interface type of encoding.TextMarshaler.
+	textMarshaler = newInterface("MarshalText", nil, []types.Type{bytesType(), errorType()})
+	// textUnmarshaler is the interface type of encoding.TextUnmarshaler.
+	textUnmarshaler = newInterface("UnmarshalText", []types.Type{bytesType()}, []types.Type{errorType()})
+)
+
+// CompliesTextMarshaler checks if a value of the given type implements encoding.TextMarshaler,
+// which has a method "MarshalText() ([]byte, error)".
+// Neither a pointer type nor an interface type complies since it can be nil.
+func CompliesTextMarshaler(t types.Type) bool {
+	return !IsPtr(t) && !types.IsInterface(t) && types.Implements(t, textMarshaler)
+}
+
+// CompliesTextUnmarshaler checks if a pointer to the given type implements encoding.TextUnmarshaler,
+// which has a method "UnmarshalText([]byte) error".
+func CompliesTextUnmarshaler(t types.Type) bool {
+	return !IsPtr(t) && !types.IsInterface(t) && types.Implements(types.NewPointer(t), textUnmarshaler)
+}
+
+// LookupParseFunc returns the function named "Parse<Type>" in the package of the named type t,
+// which takes a string and returns a value of t, optionally with an error, e.g. "func ParseStatus(string) (Status, error)".
+// It returns nil if the package doesn't have the function in the form.
+func LookupParseFunc(t types.Type) (fn *types.Func, retError bool) {
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return nil, false
+	}
+	fn, ok = named.Obj().Pkg().Scope().Lookup("Parse" + named.Obj().Name()).(*types.Func)
+	if !ok {
+		return nil, false
+	}
+
+	sig := fn.Type().(*types.Signature)
+	if sig.TypeParams() != nil || sig.Params().Len() != 1 || !types.Identical(sig.Params().At(0).Type(), StringType()) {
+		return nil, false
+	}
+	switch sig.Results().Len() {
+	case 1:
+	case 2:
+		if !IsErrorType(sig.Results().At(1).Type()) {
+			return nil, false
+		}
+	default:
+		return nil, false
+	}
+	if !types.Identical(sig.Results().At(0).Type(), t) {
+		return nil, false
+	}
+	return fn, sig.Results().Len() == 2
+}
+
+// newInterface returns an interface type that has a method of the given signature.
+func newInterface(name string, params, results []types.Type) *types.Interface {
+	toTuple := func(list []types.Type) *types.Tuple {
+		vars := make([]*types.Var, len(list))
+		for i, t := range list {
+			vars[i] = types.NewParam(token.NoPos, nil, "", t)
+		}
+		return types.NewTuple(vars...)
+	}
+	sig := types.NewSignatureType(nil, nil, nil, toTuple(params), toTuple(results), false)
+	method := types.NewFunc(token.NoPos, nil, name, sig)
+	return types.NewInterfaceType([]*types.Func{method}, nil).Complete()
+}
+
+// bytesType returns the type of []byte.
+func bytesType() types.Type {
+	return types.NewSlice(types.Typ[types.Byte])
+}
+
+// errorType returns the error type in the universe scope.
+func errorType() types.Type {
+	return types.Universe.Lookup("error").Type()
+}
+
+// MayOverflowInt returns true if src and dst are integer types, and dst cannot represent some values of src.
+// The sizes of int, uint and uintptr depend on the platform, so that a conversion from them
+// to a fixed size type, or to them from a 64-bit type, may overflow.
+func MayOverflowInt(src, dst types.Type) bool {
+	s, ok := src.Underlying().(*types.Basic)
+	if !ok || s.Info()&types.IsInteger == 0 {
+		return false
+	}
+	d, ok := dst.Underlying().(*types.Basic)
+	if !ok || d.Info()&types.IsInteger == 0 {
+		return false
+	}
+	if s.Kind() == d.Kind() {
+		return false
+	}
+
+	srcSigned := s.Info()&types.IsUnsigned == 0
+	dstSigned := d.Info()&types.IsUnsigned == 0
+	_, srcMax := intSizes(s.Kind())
+	dstMin, _ := intSizes(d.Kind())
+	switch {
+	case srcSigned && !dstSigned:
+		return true
+	case srcSigned == dstSigned:
+		return dstMin < srcMax
+	default:
+		// From unsigned into signed. A signed type needs one more bit for the same maximum.
+		return dstMin <= srcMax
+	}
+}
+
+// MayOverflowFloat returns true if src is a floating-point type, and dst is either an integer type
+// or float32 from float64, so that dst cannot represent some values of src.
+func MayOverflowFloat(src, dst types.Type) bool {
+	s, ok := src.Underlying().(*types.Basic)
+	if !ok || s.Info()&types.IsFloat == 0 {
+		return false
+	}
+	d, ok := dst.Underlying().(*types.Basic)
+	if !ok {
+		return false
+	}
+	return d.Info()&types.IsInteger != 0 || (s.Kind() == types.Float64 && d.Kind() == types.Float32)
+}
+
+// MayOverflow returns true if a typecast from src to dst may overflow;
+// see MayOverflowInt and MayOverflowFloat.
+func MayOverflow(src, dst types.Type) bool {
+	return MayOverflowInt(src, dst) || MayOverflowFloat(src, dst)
+}
+
+// intSizes returns the minimum and maximum bit sizes of the integer kind among the platforms.
+func intSizes(kind types.BasicKind) (minSize, maxSize int) {
+	switch kind {
+	case types.Int8, types.Uint8:
+		return 8, 8
+	case types.Int16, types.Uint16:
+		return 16, 16
+	case types.Int32, types.Uint32:
+		return 32, 32
+	case types.Int64, types.Uint64:
+		return 64, 64
+	default:
+		// int, uint and uintptr
+		return 32, 64
+	}
+}
@@ -2,6 +2,7 @@ package util_test
 
 import (
 	"go/ast"
+	"go/token"
 	"go/types"
 	"testing"
 
@@ -168,6 +169,18 @@ func TestIsPtr(t *testing.T) {
 	assert.True(t, util.IsPtr(nestedPtrTyp))
 }
 
+func TestIsTypedNilable(t *testing.T) {
+	assert.False(t, util.IsTypedNilable(types.Typ[types.Int]))
+	assert.False(t, util.IsTypedNilable(types.NewInterfaceType(nil, nil)))
+	assert.True(t, util.IsTypedNilable(types.NewPointer(types.Typ[types.Int])))
+	assert.True(t, util.IsTypedNilable(types.NewSlice(types.Typ[types.Int])))
+	assert.True(t, util.IsTypedNilable(types.NewMap(types.Typ[types.String], types.Typ[types.Int])))
+
+	obj := types.NewTypeName(0, nil, "IntPtr", nil)
+	named := types.NewNamed(obj, types.NewPointer(types.Typ[types.Int]), nil)
+	assert.True(t, util.IsTypedNilable(named))
+}
+
 func TestDeref(t *testing.T) {
 	t.Parallel()
 
@@ -203,6 +216,15 @@ func TestDeref(t *testing.T) {
 	actual, ok = util.Deref(nestedPtrTyp)
 	assert.True(t, ok)
 	assert.Equal(t, ptrTyp, actual)
+
+	alias := types.NewAlias(types.NewTypeName(0, nil, "IntAlias", nil), named)
+	// Test DerefPtr with aliases.
+	assert.Equal(t, named, util.DerefPtr(alias))
+	assert.Equal(t, named, util.DerefPtr(types.NewPointer(alias)))
+	// Test Deref with aliases.
+	actual, ok = util.Deref(types.NewPointer(alias))
+	assert.True(t, ok)
+	assert.Equal(t, named, actual)
 }
 
 func TestPkgOf(t *testing.T) {
@@ -254,6 +276,65 @@ func TestSliceElement(t *testing.T) {
 	assert.Equal(t, namedTyp, util.SliceElement(namedSliceTyp))
 }
 
+func TestArrayType(t *testing.T) {
+	typ := types.Typ[types.Byte]
+	arrayTyp := types.NewArray(typ, 16)
+	namedArrayTyp := types.NewNamed(types.NewTypeName(0, nil, "UUID", nil), arrayTyp, nil)
+
+	assert.False(t, util.IsArrayType(typ))
+	assert.False(t, util.IsArrayType(types.NewSlice(typ)))
+	assert.True(t, util.IsArrayType(arrayTyp))
+	assert.True(t, util.IsArrayType(namedArrayTyp))
+
+	elem, length := util.ArrayElement(typ)
+	assert.Nil(t, elem)
+	assert.Equal(t, int64(0), length)
+
+	elem, length = util.ArrayElement(types.NewSlice(typ))
+	assert.Nil(t, elem)
+	assert.Equal(t, int64(0), length)
+
+	elem, length = util.ArrayElement(arrayTyp)
+	assert.Equal(t, typ, elem)
+	assert.Equal(t, int64(16), length)
+
+	elem, length = util.ArrayElement(namedArrayTyp)
+	assert.Equal(t, typ, elem)
+	assert.Equal(t, int64(16), length)
+}
+
+func TestIsMapType(t *testing.T) {
+	t.Parallel()
+	src := `
+package custom
+
+type Labels map[string]string
+var MyMap map[string]int
+var MyLabels Labels
+var MyVar int
+`
+	_, _, pkg := loadSrc(t, src)
+
+	obj := pkg.Scope().Lookup("MyMap")
+	assert.True(t, util.IsMapType(obj.Type()))
+	obj = pkg.Scope().Lookup("MyLabels")
+	assert.True(t, util.IsMapType(obj.Type()))
+	obj = pkg.Scope().Lookup("MyVar")
+	assert.False(t, util.IsMapType(obj.Type()))
+}
+
+func TestMapKeyElem(t *testing.T) {
+	mapTyp := types.NewMap(types.Typ[types.String], types.Typ[types.Int])
+
+	key, elem := util.MapKeyElem(mapTyp)
+	assert.Equal(t, types.Typ[types.String], key)
+	assert.Equal(t, types.Typ[types.Int], elem)
+
+	key, elem = util.MapKeyElem(types.Typ[types.Int])
+	assert.Nil(t, key)
+	assert.Nil(t, elem)
+}
+
 func TestGetDocCommentOn(t *testing.T) {
 	t.Parallel()
 	// Define the source code to test.
@@ -440,6 +521,104 @@ type S struct{
 	assert.Equal(t, "Foo", f.Name())
 }
 
+func TestZeroValueExpr(t *testing.T) {
+	t.Parallel()
+	named := types.NewNamed(types.NewTypeName(token.NoPos, nil, "User", nil), types.NewStruct(nil, nil), nil)
+	status := types.NewNamed(types.NewTypeName(token.NoPos, nil, "Status", nil), types.Typ[types.Int], nil)
+
+	assert.Equal(t, "false", util.ZeroValueExpr(types.Typ[types.Bool], "bool"))
+	assert.Equal(t, `""`, util.ZeroValueExpr(types.Typ[types.String], "string"))
+	assert.Equal(t, "0", util.ZeroValueExpr(types.Typ[types.Float64], "float64"))
+	assert.Equal(t, "0", util.ZeroValueExpr(status, "Status"))
+	assert.Equal(t, "User{}", util.ZeroValueExpr(named, "User"))
+	assert.Equal(t, "[2]int{}", util.ZeroValueExpr(types.NewArray(types.Typ[types.Int], 2), "[2]int"))
+	assert.Equal(t, "nil", util.ZeroValueExpr(types.NewPointer(named), "*User"))
+	assert.Equal(t, "nil", util.ZeroValueExpr(types.NewSlice(named), "[]User"))
+}
+
+func TestNullableValueField(t *testing.T) {
+	t.Parallel()
+	source := `
+package main
+
+type NullString struct {
+	String string
+	Valid  bool
+}
+
+type Null[T any] struct {
+	V     T
+	Valid bool
+}
+
+type NoValid struct {
+	String string
+	Ok     bool
+}
+
+type TooMany struct {
+	String string
+	Valid  bool
+	Extra  int
+}
+
+var nullInt Null[int]
+`
+	_, _, pkg := loadSrc(t, source)
+	lookup := func(name string) types.Type {
+		return pkg.Scope().Lookup(name).Type()
+	}
+
+	field := util.NullableValueField(lookup("NullString"))
+	require.NotNil(t, field)
+	assert.Equal(t, "String", field.Name())
+
+	field = util.NullableValueField(lookup("nullInt"))
+	require.NotNil(t, field)
+	assert.Equal(t, "V", field.Name())
+	assert.Equal(t, "int", field.Type().String())
+
+	assert.Nil(t, util.NullableValueField(lookup("NoValid")))
+	assert.Nil(t, util.NullableValueField(lookup("TooMany")))
+	assert.Nil(t, util.NullableValueField(lookup("NullString").Underlying()))
+	assert.Nil(t, util.NullableValueField(types.NewPointer(lookup("NullString"))))
+}
+
+func TestLookupTagValue(t *testing.T) {
+	t.Parallel()
+	source := `
+package main
+
+type S struct{
+	Foo  int    ` + "`json:\"foo,omitempty\" db:\"foo_col\"`" + `
+	Bar  string ` + "`json:\"-\"`" + `
+	Baz  string ` + "`json:\",omitempty\"`" + `
+	Qux  string
+}
+`
+	_, _, pkg := loadSrc(t, source)
+	typ := pkg.Scope().Lookup("S").Type()
+
+	lookup := func(name, key string) (string, bool) {
+		return util.LookupTagValue(typ, util.FindField(typ, name, true), key)
+	}
+
+	value, ok := lookup("Foo", "json")
+	assert.True(t, ok)
+	assert.Equal(t, "foo", value)
+
+	value, ok = lookup("Foo", "db")
+	assert.True(t, ok)
+	assert.Equal(t, "foo_col", value)
+
+	_, ok = lookup("Bar", "json")
+	assert.False(t, ok)
+	_, ok = lookup("Baz", "json")
+	assert.False(t, ok)
+	_, ok = lookup("Qux", "json")
+	assert.False(t, ok)
+}
+
 func TestGetMethodReturnTypes(t *testing.T) {
 	t.Parallel()
 	// Define the source code to test.
@@ -567,3 +746,158 @@ func V() string {
 		})
 	}
 }
+
+func TestCompliesText(t *testing.T) {
+	t.Parallel()
+
+	source := `
+package main
+
+type M struct{}
+
+func (M) MarshalText() ([]byte, error) { return nil, nil }
+
+type U struct{}
+
+func (*U) UnmarshalText([]byte) error { return nil }
+
+type W struct{}
+
+func (W) MarshalText() []byte { return nil }
+
+type I interface {
+	MarshalText() ([]byte, error)
+}
+
+var PM *M
+`
+	_, _, pkg := loadSrc(t, source)
+
+	cases := []struct {
+		name        string
+		marshaler   bool
+		unmarshaler bool
+	}{
+		{"M", true, false},
+		{"U", false, true},
+		{"W", false, false},
+		{"I", false, false},
+		{"PM", false, false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := pkg.Scope().Lookup(tt.name)
+			assert.Equal(t, tt.marshaler, util.CompliesTextMarshaler(obj.Type()))
+			assert.Equal(t, tt.unmarshaler, util.CompliesTextUnmarshaler(obj.Type()))
+		})
+	}
+}
+
+func TestLookupParseFunc(t *testing.T) {
+	t.Parallel()
+
+	source := `
+package main
+
+type A int
+
+func ParseA(string) (A, error) { return 0, nil }
+
+type B int
+
+func ParseB(string) B { return 0 }
+
+type C int
+
+func ParseC([]byte) (C, error) { return 0, nil }
+
+type D int
+
+func ParseD(string) (*D, error) { return nil, nil }
+
+type E int
+
+func ParseE(string) (E, bool) { return 0, false }
+
+type F int
+`
+	_, _, pkg := loadSrc(t, source)
+
+	cases := []struct {
+		name     string
+		found    bool
+		retError bool
+	}{
+		{"A", true, true},
+		{"B", true, false},
+		{"C", false, false},
+		{"D", false, false},
+		{"E", false, false},
+		{"F", false, false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := pkg.Scope().Lookup(tt.name)
+			fn, retError := util.LookupParseFunc(obj.Type())
+			assert.Equal(t, tt.found, fn != nil)
+			assert.Equal(t, tt.retError, retError)
+		})
+	}
+}
+
+func TestMayOverflowInt(t *testing.T) {
+	t.Parallel()
+
+	named := types.NewNamed(types.NewTypeName(token.NoPos, nil, "Count", nil), types.Typ[types.Int16], nil)
+	cases := []struct {
+		src, dst types.BasicKind
+		expected bool
+	}{
+		{types.Int64, types.Int32, true},
+		{types.Int32, types.Int64, false},
+		{types.Int, types.Int32, true},
+		{types.Int32, types.Int, false},
+		{types.Int64, types.Int, true},
+		{types.Int, types.Int64, false},
+		{types.Int8, types.Uint64, true},
+		{types.Uint8, types.Int16, false},
+		{types.Uint8, types.Int8, true},
+		{types.Uint32, types.Int, true},
+		{types.Uint32, types.Int64, false},
+		{types.Uint, types.Int64, true},
+		{types.Uint64, types.Uint, true},
+		{types.Uint, types.Uint64, false},
+		{types.Float64, types.Int32, false},
+		{types.Int64, types.Float32, false},
+	}
+	for _, tt := range cases {
+		src, dst := types.Typ[tt.src], types.Typ[tt.dst]
+		assert.Equal(t, tt.expected, util.MayOverflowInt(src, dst), "%v to %v", src, dst)
+	}
+
+	assert.True(t, util.MayOverflowInt(types.Typ[types.Int32], named))
+	assert.False(t, util.MayOverflowInt(named, types.Typ[types.Int16]))
+}
+
+func TestMayOverflowFloat(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		src, dst types.BasicKind
+		expected bool
+	}{
+		{types.Float64, types.Float32, true},
+		{types.Float32, types.Float64, false},
+		{types.Float32, types.Int64, true},
+		{types.Float64, types.Uint8, true},
+		{types.Int64, types.Float32, false},
+		{types.Int64, types.Int32, false},
+	}
+	for _, tt := range cases {
+		src, dst := types.Typ[tt.src], types.Typ[tt.dst]
+		assert.Equal(t, tt.expected, util.MayOverflowFloat(src, dst), "%v to %v", src, dst)
+	}
+	assert.True(t, util.MayOverflow(types.Typ[types.Int64], types.Typ[types.Int32]))
+}
@@ -0,0 +1,120 @@
+// Code generated by github.com/reedom/convergen
+// DO NOT EDIT.
+
+package arrays
+
+import "fmt"
+
+type UUID [16]byte
+
+type Point struct {
+	X, Y float64
+}
+
+type PointModel struct {
+	X, Y float64
+}
+
+type Shape struct {
+	ID       UUID
+	Origin   [3]float64
+	Checksum [4]byte
+	Corners  [2]Point
+	Tags     []string
+}
+
+type ShapeModel struct {
+	ID       []byte
+	Origin   [3]float32
+	Checksum []byte
+	Corners  [2]PointModel
+	Tags     [2]string
+}
+
+func FromModel(src *ShapeModel) (dst *Shape, err error) {
+	dst = &Shape{}
+	if len(src.ID) != 16 {
+		err = fmt.Errorf("src.ID has %d elements, but dst.ID needs 16", len(src.ID))
+	} else {
+		for i, e := range src.ID {
+			dst.ID[i] = e
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	// skip: dst.Origin
+	if len(src.Checksum) != 4 {
+		err = fmt.Errorf("src.Checksum has %d elements, but dst.Checksum needs 4", len(src.Checksum))
+	} else {
+		for i, e := range src.Checksum {
+			dst.Checksum[i] = e
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i, e := range src.Corners {
+		dst.Corners[i] = *copyPointModelToPoint(&e)
+	}
+	dst.Tags = make([]string, len(src.Tags))
+	for i, e := range src.Tags {
+		dst.Tags[i] = e
+	}
+
+	return
+}
+
+func ToModel(src *Shape) (dst *ShapeModel, err error) {
+	dst = &ShapeModel{}
+	dst.ID = make([]byte, len(src.ID))
+	for i, e := range src.ID {
+		dst.ID[i] = e
+	}
+	for i, e := range src.Origin {
+		dst.Origin[i] = float32(e)
+	}
+	dst.Checksum = make([]byte, len(src.Checksum))
+	for i, e := range src.Checksum {
+		dst.Checksum[i] = e
+	}
+	for i, e := range src.Corners {
+		dst.Corners[i] = *copyPointToPointModel(&e)
+	}
+	if len(src.Tags) != 2 {
+		err = fmt.Errorf("src.Tags has %d elements, but dst.Tags needs 2", len(src.Tags))
+	} else {
+		for i, e := range src.Tags {
+			dst.Tags[i] = e
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// copyPointModelToPoint copies PointModel into Point.
+func copyPointModelToPoint(src *PointModel) (dst *Point) {
+	if src == nil {
+		return
+	}
+	dst = &Point{}
+	dst.X = src.X
+	dst.Y = src.Y
+
+	return
+}
+
+// copyPointToPointModel copies Point into PointModel.
+func copyPointToPointModel(src *Point) (dst *PointModel) {
+	if src == nil {
+		return
+	}
+	dst = &PointModel{}
+	dst.X = src.X
+	dst.Y = src.Y
+
+	return
+}
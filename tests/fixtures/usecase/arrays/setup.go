@@ -0,0 +1,39 @@
+//go:build convergen
+
+package arrays
+
+type UUID [16]byte
+
+type Point struct {
+	X, Y float64
+}
+
+type PointModel struct {
+	X, Y float64
+}
+
+type Shape struct {
+	ID       UUID
+	Origin   [3]float64
+	Checksum [4]byte
+	Corners  [2]Point
+	Tags     []string
+}
+
+type ShapeModel struct {
+	ID       []byte
+	Origin   [3]float32
+	Checksum []byte
+	Corners  [2]PointModel
+	Tags     [2]string
+}
+
+//go:generate go run github.com/reedom/convergen
+type Convergen interface {
+	// :typecast
+	ToModel(*Shape) (*ShapeModel, error)
+
+	// :typecast
+	// :skip Origin
+	FromModel(*ShapeModel) (*Shape, error)
+}
@@ -0,0 +1,133 @@
+// Code generated by github.com/reedom/convergen
+// DO NOT EDIT.
+
+package builtin
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/reedom/convergen/v8/pkg/stdconv"
+)
+
+type UUID [16]byte
+
+type Event struct {
+	ID        UUID
+	CreatedAt time.Time
+	Timeout   time.Duration
+	Count     int
+	Total     int64
+	Rate      float64
+	Enabled   bool
+	Payload   []byte
+	Codes     []int
+}
+
+type EventModel struct {
+	ID        string
+	CreatedAt int64
+	Timeout   int64
+	Count     string
+	Total     string
+	Rate      string
+	Enabled   string
+	Payload   string
+	Codes     []string
+}
+
+func FromModel(src *EventModel) (dst *Event, err error) {
+	dst = &Event{}
+	dst.ID, err = stdconv.ParseUUID(src.ID)
+	if err != nil {
+		return nil, err
+	}
+	dst.CreatedAt = time.UnixMilli(src.CreatedAt)
+	dst.Timeout = time.Duration(src.Timeout)
+	dst.Count, err = strconv.Atoi(src.Count)
+	if err != nil {
+		return nil, err
+	}
+	dst.Total, err = stdconv.ParseInt64(src.Total)
+	if err != nil {
+		return nil, err
+	}
+	dst.Rate, err = stdconv.ParseFloat64(src.Rate)
+	if err != nil {
+		return nil, err
+	}
+	dst.Enabled, err = strconv.ParseBool(src.Enabled)
+	if err != nil {
+		return nil, err
+	}
+	dst.Payload = []byte(src.Payload)
+	if src.Codes != nil {
+		dst.Codes = make([]int, len(src.Codes))
+		for i, e := range src.Codes {
+			dst.Codes[i], err = strconv.Atoi(e)
+			if err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func FromModelNoError(src *EventModel) (dst *Event) {
+	dst = &Event{}
+	// skip: dst.ID
+	dst.CreatedAt = time.UnixMilli(src.CreatedAt)
+	dst.Timeout = time.Duration(src.Timeout)
+	// no match: dst.Count
+	// no match: dst.Total
+	// no match: dst.Rate
+	// no match: dst.Enabled
+	// skip: dst.Payload
+	// skip: dst.Codes
+
+	return
+}
+
+func ToModel(src *Event) (dst *EventModel) {
+	dst = &EventModel{}
+	dst.ID = stdconv.FormatUUID(src.ID)
+	dst.CreatedAt = stdconv.UnixMilli(src.CreatedAt)
+	dst.Timeout = int64(src.Timeout)
+	dst.Count = strconv.Itoa(src.Count)
+	dst.Total = stdconv.FormatInt64(src.Total)
+	dst.Rate = stdconv.FormatFloat64(src.Rate)
+	dst.Enabled = strconv.FormatBool(src.Enabled)
+	dst.Payload = string(src.Payload)
+	if src.Codes != nil {
+		dst.Codes = make([]string, len(src.Codes))
+		for i, e := range src.Codes {
+			dst.Codes[i] = strconv.Itoa(e)
+		}
+	}
+
+	return
+}
+
+func ToModelTypecast(src *Event) (dst *EventModel) {
+	dst = &EventModel{}
+	dst.ID = stdconv.FormatUUID(src.ID)
+	dst.CreatedAt = stdconv.UnixMilli(src.CreatedAt)
+	dst.Timeout = int64(src.Timeout)
+	dst.Count = strconv.Itoa(src.Count)
+	dst.Total = stdconv.FormatInt64(src.Total)
+	dst.Rate = stdconv.FormatFloat64(src.Rate)
+	dst.Enabled = strconv.FormatBool(src.Enabled)
+	dst.Payload = string(src.Payload)
+	if src.Codes != nil {
+		dst.Codes = make([]string, len(src.Codes))
+		for i, e := range src.Codes {
+			dst.Codes[i] = strconv.Itoa(e)
+		}
+	}
+
+	return
+}
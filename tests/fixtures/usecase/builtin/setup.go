@@ -0,0 +1,51 @@
+//go:build convergen
+
+package builtin
+
+import (
+	"time"
+)
+
+type UUID [16]byte
+
+type Event struct {
+	ID        UUID
+	CreatedAt time.Time
+	Timeout   time.Duration
+	Count     int
+	Total     int64
+	Rate      float64
+	Enabled   bool
+	Payload   []byte
+	Codes     []int
+}
+
+type EventModel struct {
+	ID        string
+	CreatedAt int64
+	Timeout   int64
+	Count     string
+	Total     string
+	Rate      string
+	Enabled   string
+	Payload   string
+	Codes     []string
+}
+
+// :builtin time,strconv
+// :builtin bytes uuid
+//
+//go:generate go run github.com/reedom/convergen
+type Convergen interface {
+	ToModel(*Event) *EventModel
+	FromModel(*EventModel) (*Event, error)
+	// :typecast
+	ToModelTypecast(*Event) *EventModel
+
+	// :builtin:off
+	// :builtin time,strconv
+	// :skip ID
+	// :skip Payload
+	// :skip Codes
+	FromModelNoError(*EventModel) *Event
+}
@@ -0,0 +1,78 @@
+// Code generated by github.com/reedom/convergen
+// DO NOT EDIT.
+
+package checkedcast
+
+import "github.com/reedom/convergen/v8/pkg/stdconv"
+
+type Port uint16
+
+type Stats struct {
+	Count  int64
+	Port   int
+	Offset int32
+	Ratio  float64
+	Score  float64
+	Sizes  []int64
+}
+
+type StatsModel struct {
+	Count  int32
+	Port   Port
+	Offset int64
+	Ratio  float32
+	Score  int16
+	Sizes  []uint32
+}
+
+func ToModel(src *Stats) (dst *StatsModel, err error) {
+	dst = &StatsModel{}
+	dst.Count, err = stdconv.CastInt[int32](src.Count, "src.Count")
+	if err != nil {
+		return nil, err
+	}
+	dst.Port, err = stdconv.CastInt[Port](src.Port, "src.Port")
+	if err != nil {
+		return nil, err
+	}
+	dst.Offset = int64(src.Offset)
+	dst.Ratio, err = stdconv.CastFloat[float32](src.Ratio, "src.Ratio")
+	if err != nil {
+		return nil, err
+	}
+	dst.Score, err = stdconv.CastFloatToInt[int16](src.Score, "src.Score")
+	if err != nil {
+		return nil, err
+	}
+	if src.Sizes != nil {
+		dst.Sizes = make([]uint32, len(src.Sizes))
+		for i, e := range src.Sizes {
+			dst.Sizes[i], err = stdconv.CastInt[uint32](e, "src.Sizes[i]")
+			if err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func ToModelUnchecked(src *Stats) (dst *StatsModel) {
+	dst = &StatsModel{}
+	dst.Count = int32(src.Count)
+	dst.Port = Port(src.Port)
+	dst.Offset = int64(src.Offset)
+	dst.Ratio = float32(src.Ratio)
+	dst.Score = int16(src.Score)
+	if src.Sizes != nil {
+		dst.Sizes = make([]uint32, len(src.Sizes))
+		for i, e := range src.Sizes {
+			dst.Sizes[i] = uint32(e)
+		}
+	}
+
+	return
+}
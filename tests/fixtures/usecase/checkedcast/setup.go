@@ -0,0 +1,33 @@
+//go:build convergen
+
+package checkedcast
+
+type Port uint16
+
+type Stats struct {
+	Count  int64
+	Port   int
+	Offset int32
+	Ratio  float64
+	Score  float64
+	Sizes  []int64
+}
+
+type StatsModel struct {
+	Count  int32
+	Port   Port
+	Offset int64
+	Ratio  float32
+	Score  int16
+	Sizes  []uint32
+}
+
+// :typecast checked
+//
+//go:generate go run github.com/reedom/convergen
+type Convergen interface {
+	ToModel(*Stats) (*StatsModel, error)
+
+	// :typecast
+	ToModelUnchecked(*Stats) *StatsModel
+}
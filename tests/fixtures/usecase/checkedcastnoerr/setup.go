@@ -0,0 +1,17 @@
+//go:build convergen
+
+package checkedcastnoerr
+
+type Stats struct {
+	Count int64
+}
+
+type StatsModel struct {
+	Count int32
+}
+
+//go:generate go run github.com/reedom/convergen
+type Convergen interface {
+	// :typecast checked
+	ToModel(*Stats) *StatsModel
+}
@@ -0,0 +1,78 @@
+// Code generated by github.com/reedom/convergen
+// DO NOT EDIT.
+
+package convelem
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/reedom/convergen/v8/pkg/stdconv"
+)
+
+type Tag struct {
+	Name string
+}
+
+type Post struct {
+	Tags     []Tag
+	Top      [3]Tag
+	Labels   map[string]Tag
+	Pinned   *Tag
+	Keywords []string
+}
+
+type PostModel struct {
+	Tags     []string
+	Top      [3]string
+	Labels   map[string]string
+	Pinned   *string
+	Keywords []Tag
+}
+
+func ToModel(src *Post) (dst *PostModel, err error) {
+	dst = &PostModel{}
+	if src.Tags != nil {
+		dst.Tags = make([]string, len(src.Tags))
+		for i, e := range src.Tags {
+			dst.Tags[i] = tagName(e)
+		}
+	}
+	for i, e := range src.Top {
+		dst.Top[i] = tagName(e)
+	}
+	if src.Labels != nil {
+		dst.Labels = make(map[string]string, len(src.Labels))
+		for k, v := range src.Labels {
+			dst.Labels[k] = tagName(v)
+		}
+	}
+	if src.Pinned != nil {
+		dst.Pinned = stdconv.Ptr(tagName(*src.Pinned))
+	}
+	if src.Keywords != nil {
+		dst.Keywords = make([]Tag, len(src.Keywords))
+		for i, e := range src.Keywords {
+			dst.Keywords[i], err = parseTag(e)
+			if err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func tagName(tag Tag) string {
+	return tag.Name
+}
+
+func parseTag(s string) (Tag, error) {
+	if strings.TrimSpace(s) == "" {
+		return Tag{}, errors.New("empty tag")
+	}
+	return Tag{Name: s}, nil
+}
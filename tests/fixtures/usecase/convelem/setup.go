@@ -0,0 +1,49 @@
+//go:build convergen
+
+package convelem
+
+import (
+	"errors"
+	"strings"
+)
+
+type Tag struct {
+	Name string
+}
+
+type Post struct {
+	Tags     []Tag
+	Top      [3]Tag
+	Labels   map[string]Tag
+	Pinned   *Tag
+	Keywords []string
+}
+
+type PostModel struct {
+	Tags     []string
+	Top      [3]string
+	Labels   map[string]string
+	Pinned   *string
+	Keywords []Tag
+}
+
+//go:generate go run github.com/reedom/convergen
+type Convergen interface {
+	// :conv tagName Tags
+	// :conv tagName Top
+	// :conv tagName Labels
+	// :conv tagName Pinned
+	// :conv parseTag Keywords
+	ToModel(*Post) (*PostModel, error)
+}
+
+func tagName(tag Tag) string {
+	return tag.Name
+}
+
+func parseTag(s string) (Tag, error) {
+	if strings.TrimSpace(s) == "" {
+		return Tag{}, errors.New("empty tag")
+	}
+	return Tag{Name: s}, nil
+}
@@ -0,0 +1,76 @@
+// Code generated by github.com/reedom/convergen
+// DO NOT EDIT.
+
+package convtype
+
+import (
+	"strconv"
+	"time"
+)
+
+type Event struct {
+	Name      string
+	StartAt   time.Time
+	Schedule  Schedule
+	Reminders []time.Time
+	Count     string
+}
+
+type Schedule struct {
+	EndAt time.Time
+}
+
+type EventModel struct {
+	Name      string
+	StartAt   string
+	Schedule  ScheduleModel
+	Reminders []string
+	Count     int
+}
+
+type ScheduleModel struct {
+	EndAt string
+}
+
+func ToModel(src *Event) (dst *EventModel, err error) {
+	dst = &EventModel{}
+	dst.Name = src.Name
+	dst.StartAt = formatTime(src.StartAt)
+	dst.Schedule.EndAt = formatTime(src.Schedule.EndAt)
+	if src.Reminders != nil {
+		dst.Reminders = make([]string, len(src.Reminders))
+		for i, e := range src.Reminders {
+			dst.Reminders[i] = formatTime(e)
+		}
+	}
+	dst.Count, err = parseCount(src.Count)
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func ToModelWithoutCount(src *Event) (dst *EventModel) {
+	dst = &EventModel{}
+	dst.Name = src.Name
+	dst.StartAt = formatTime(src.StartAt)
+	dst.Schedule.EndAt = formatTime(src.Schedule.EndAt)
+	if src.Reminders != nil {
+		dst.Reminders = make([]string, len(src.Reminders))
+		for i, e := range src.Reminders {
+			dst.Reminders[i] = formatTime(e)
+		}
+	}
+	// skip: dst.Count
+
+	return
+}
+
+func formatTime(t time.Time) string {
+	return t.Format(time.RFC3339)
+}
+
+func parseCount(s string) (int, error) {
+	return strconv.Atoi(s)
+}
@@ -0,0 +1,51 @@
+//go:build convergen
+
+package convtype
+
+import (
+	"strconv"
+	"time"
+)
+
+type Event struct {
+	Name      string
+	StartAt   time.Time
+	Schedule  Schedule
+	Reminders []time.Time
+	Count     string
+}
+
+type Schedule struct {
+	EndAt time.Time
+}
+
+type EventModel struct {
+	Name      string
+	StartAt   string
+	Schedule  ScheduleModel
+	Reminders []string
+	Count     int
+}
+
+type ScheduleModel struct {
+	EndAt string
+}
+
+// :conv:type formatTime time.Time
+//
+//go:generate go run github.com/reedom/convergen
+type Convergen interface {
+	// :conv:type parseCount string int
+	ToModel(*Event) (*EventModel, error)
+
+	// :skip Count
+	ToModelWithoutCount(*Event) *EventModel
+}
+
+func formatTime(t time.Time) string {
+	return t.Format(time.RFC3339)
+}
+
+func parseCount(s string) (int, error) {
+	return strconv.Atoi(s)
+}
@@ -0,0 +1,73 @@
+// Code generated by github.com/reedom/convergen
+// DO NOT EDIT.
+
+package convwith
+
+import (
+	"errors"
+	"fmt"
+)
+
+type User struct {
+	ID    int
+	First string
+	Last  string
+	Age   int32
+}
+
+type UserView struct {
+	ID       int
+	FullName string
+	Label    string
+	Age      int64
+	Greeting string
+}
+
+type Locale string
+
+func ToView(src *User, arg0 Locale) (dst *UserView, err error) {
+	dst = &UserView{}
+	dst.ID = src.ID
+	dst.FullName = fullName(*src)
+	dst.Label, err = label(src, arg0)
+	if err != nil {
+		return nil, err
+	}
+	dst.Age = int64(src.Age)
+	dst.Greeting = greeting(src, arg0)
+
+	return
+}
+
+func ToViewSimple(src *User) (dst *UserView) {
+	dst = &UserView{}
+	dst.ID = src.ID
+	dst.FullName = fullName(*src)
+	// skip: dst.Label
+	dst.Age = ageOf(src)
+	// skip: dst.Greeting
+
+	return
+}
+
+func fullName(u User) string {
+	return u.First + " " + u.Last
+}
+
+func label(u *User, locale Locale) (string, error) {
+	if u.ID == 0 {
+		return "", errors.New("no ID")
+	}
+	return fmt.Sprintf("%v#%d", locale, u.ID), nil
+}
+
+func greeting(u *User, locale Locale) string {
+	if locale == "ja" {
+		return "こんにちは、" + u.First
+	}
+	return "Hello, " + u.First
+}
+
+func ageOf(u *User) int64 {
+	return int64(u.Age)
+}
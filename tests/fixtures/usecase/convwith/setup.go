@@ -0,0 +1,62 @@
+//go:build convergen
+
+package convwith
+
+import (
+	"errors"
+	"fmt"
+)
+
+type User struct {
+	ID    int
+	First string
+	Last  string
+	Age   int32
+}
+
+type UserView struct {
+	ID       int
+	FullName string
+	Label    string
+	Age      int64
+	Greeting string
+}
+
+type Locale string
+
+//go:generate go run github.com/reedom/convergen
+type Convergen interface {
+	// :typecast
+	// :conv:with fullName FullName
+	// :conv:with label Label
+	// :conv:with greeting Greeting
+	ToView(*User, Locale) (*UserView, error)
+
+	// :conv:with fullName FullName
+	// :conv:with ageOf Age
+	// :skip Label
+	// :skip Greeting
+	ToViewSimple(*User) *UserView
+}
+
+func fullName(u User) string {
+	return u.First + " " + u.Last
+}
+
+func label(u *User, locale Locale) (string, error) {
+	if u.ID == 0 {
+		return "", errors.New("no ID")
+	}
+	return fmt.Sprintf("%v#%d", locale, u.ID), nil
+}
+
+func greeting(u *User, locale Locale) string {
+	if locale == "ja" {
+		return "こんにちは、" + u.First
+	}
+	return "Hello, " + u.First
+}
+
+func ageOf(u *User) int64 {
+	return int64(u.Age)
+}
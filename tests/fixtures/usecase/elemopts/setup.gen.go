@@ -0,0 +1,82 @@
+// Code generated by github.com/reedom/convergen
+// DO NOT EDIT.
+
+package elemopts
+
+type Src struct {
+	Items []Item
+}
+
+type Dst struct {
+	Items []DstItem
+}
+
+type Item struct {
+	ID   int32
+	Name string
+}
+
+type DstItem struct {
+	ID   int64
+	Name string
+}
+
+func CopyDst(src *Src) (dst *Dst) {
+	dst = &Dst{}
+	if src.Items != nil {
+		dst.Items = make([]DstItem, len(src.Items))
+		for i, e := range src.Items {
+			dst.Items[i] = *copyItemToDstItem(&e)
+		}
+	}
+
+	return
+}
+
+func ToDst(src *Src) (dst *Dst) {
+	dst = &Dst{}
+	if src.Items != nil {
+		dst.Items = make([]DstItem, len(src.Items))
+		for i, e := range src.Items {
+			dst.Items[i] = *copyItemToDstItem(&e)
+		}
+	}
+
+	return
+}
+
+func ToDstNamesOnly(src *Src) (dst *Dst) {
+	dst = &Dst{}
+	if src.Items != nil {
+		dst.Items = make([]DstItem, len(src.Items))
+		for i, e := range src.Items {
+			dst.Items[i] = *copyItemToDstItem2(&e)
+		}
+	}
+
+	return
+}
+
+// copyItemToDstItem copies Item into DstItem.
+func copyItemToDstItem(src *Item) (dst *DstItem) {
+	if src == nil {
+		return
+	}
+	dst = &DstItem{}
+	dst.ID = int64(src.ID)
+	dst.Name = src.Name
+
+	return
+}
+
+// copyItemToDstItem2 copies Item into DstItem.
+func copyItemToDstItem2(src *Item) (dst *DstItem) {
+	if src == nil {
+		return
+	}
+	dst = &DstItem{}
+	// no match: dst.ID
+	dst.Name = src.Name
+
+	return
+}
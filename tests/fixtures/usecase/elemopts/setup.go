@@ -0,0 +1,30 @@
+//go:build convergen
+
+package elemopts
+
+type Src struct {
+	Items []Item
+}
+
+type Dst struct {
+	Items []DstItem
+}
+
+type Item struct {
+	ID   int32
+	Name string
+}
+
+type DstItem struct {
+	ID   int64
+	Name string
+}
+
+//go:generate go run github.com/reedom/convergen
+type Convergen interface {
+	// :typecast
+	ToDst(*Src) *Dst
+	ToDstNamesOnly(*Src) *Dst
+	// :typecast
+	CopyDst(*Src) *Dst
+}
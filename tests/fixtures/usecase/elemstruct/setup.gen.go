@@ -0,0 +1,123 @@
+// Code generated by github.com/reedom/convergen
+// DO NOT EDIT.
+
+package elemstruct
+
+import (
+	"github.com/reedom/convergen/v8/tests/fixtures/data/domain"
+	"github.com/reedom/convergen/v8/tests/fixtures/data/model"
+)
+
+type SrcModel struct {
+	Pets      []*domain.Pet
+	Items     []Item
+	ItemRefs  []*Item
+	ItemsByID map[int]Item
+	Owners    map[string]*Owner
+}
+
+type DstModel struct {
+	Pets      []*model.Pet
+	Items     []DstItem
+	ItemRefs  []*DstItem
+	ItemsByID map[int]*DstItem
+	Owners    map[string]*DstOwner
+}
+
+type Item struct {
+	ID   int
+	Name string
+}
+
+type DstItem struct {
+	ID   int64
+	Name string
+}
+
+type Owner struct {
+	Name string
+}
+
+type DstOwner struct {
+	Name string
+}
+
+func Copy(src *SrcModel) (dst *DstModel) {
+	dst = &DstModel{}
+	if src.Pets != nil {
+		dst.Pets = make([]*model.Pet, len(src.Pets))
+		for i, e := range src.Pets {
+			dst.Pets[i] = copyDomainPetToModelPet(e)
+		}
+	}
+	if src.Items != nil {
+		dst.Items = make([]DstItem, len(src.Items))
+		for i, e := range src.Items {
+			dst.Items[i] = *copyItemToDstItem(&e)
+		}
+	}
+	if src.ItemRefs != nil {
+		dst.ItemRefs = make([]*DstItem, len(src.ItemRefs))
+		for i, e := range src.ItemRefs {
+			dst.ItemRefs[i] = copyItemToDstItem(e)
+		}
+	}
+	if src.ItemsByID != nil {
+		dst.ItemsByID = make(map[int]*DstItem, len(src.ItemsByID))
+		for k, v := range src.ItemsByID {
+			dst.ItemsByID[k] = copyItemToDstItem(&v)
+		}
+	}
+	if src.Owners != nil {
+		dst.Owners = make(map[string]*DstOwner, len(src.Owners))
+		for k, v := range src.Owners {
+			dst.Owners[k] = copyOwnerToDstOwner(v)
+		}
+	}
+
+	return
+}
+
+// copyDomainPetToModelPet copies domain.Pet into model.Pet.
+func copyDomainPetToModelPet(src *domain.Pet) (dst *model.Pet) {
+	if src == nil {
+		return
+	}
+	dst = &model.Pet{}
+	dst.ID = uint64(src.ID)
+	// no match: dst.Category.CategoryID
+	dst.Category.Name = src.Category.Name
+	dst.Name = src.Name
+	if src.PhotoUrls != nil {
+		dst.PhotoUrls = make([]string, len(src.PhotoUrls))
+		for i, e := range src.PhotoUrls {
+			dst.PhotoUrls[i] = e.String()
+		}
+	}
+	dst.Status = src.Status.String()
+
+	return
+}
+
+// copyItemToDstItem copies Item into DstItem.
+func copyItemToDstItem(src *Item) (dst *DstItem) {
+	if src == nil {
+		return
+	}
+	dst = &DstItem{}
+	dst.ID = int64(src.ID)
+	dst.Name = src.Name
+
+	return
+}
+
+// copyOwnerToDstOwner copies Owner into DstOwner.
+func copyOwnerToDstOwner(src *Owner) (dst *DstOwner) {
+	if src == nil {
+		return
+	}
+	dst = &DstOwner{}
+	dst.Name = src.Name
+
+	return
+}
@@ -0,0 +1,49 @@
+//go:build convergen
+
+package elemstruct
+
+import (
+	"github.com/reedom/convergen/v8/tests/fixtures/data/domain"
+	"github.com/reedom/convergen/v8/tests/fixtures/data/model"
+)
+
+type SrcModel struct {
+	Pets      []*domain.Pet
+	Items     []Item
+	ItemRefs  []*Item
+	ItemsByID map[int]Item
+	Owners    map[string]*Owner
+}
+
+type DstModel struct {
+	Pets      []*model.Pet
+	Items     []DstItem
+	ItemRefs  []*DstItem
+	ItemsByID map[int]*DstItem
+	Owners    map[string]*DstOwner
+}
+
+type Item struct {
+	ID   int
+	Name string
+}
+
+type DstItem struct {
+	ID   int64
+	Name string
+}
+
+type Owner struct {
+	Name string
+}
+
+type DstOwner struct {
+	Name string
+}
+
+//go:generate go run github.com/reedom/convergen
+type Convergen interface {
+	// :typecast
+	// :stringer
+	Copy(*SrcModel) *DstModel
+}
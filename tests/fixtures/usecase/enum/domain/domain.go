@@ -0,0 +1,24 @@
+package domain
+
+type Status int
+
+const (
+	StatusActive Status = iota + 1
+	StatusInactive
+	StatusBanned
+	StatusDefault = StatusActive
+)
+
+type Role string
+
+const (
+	RoleAdmin  Role = "admin"
+	RoleMember Role = "member"
+)
+
+type User struct {
+	Name   string
+	Status Status
+	Roles  []Role
+	Role   Role
+}
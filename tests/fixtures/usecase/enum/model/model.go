@@ -0,0 +1,23 @@
+package model
+
+type Status int
+
+const (
+	Inactive Status = iota
+	Active
+	Banned
+)
+
+type Role int
+
+const (
+	AdminRole Role = iota
+	MemberRole
+)
+
+type User struct {
+	Name   string
+	Status Status
+	Roles  []Role
+	Role   Role
+}
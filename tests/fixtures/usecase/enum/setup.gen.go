@@ -0,0 +1,74 @@
+// Code generated by github.com/reedom/convergen
+// DO NOT EDIT.
+
+package enum
+
+import (
+	"github.com/reedom/convergen/v8/tests/fixtures/usecase/enum/domain"
+	"github.com/reedom/convergen/v8/tests/fixtures/usecase/enum/model"
+)
+
+func DomainToModel(src *domain.User) (dst *model.User) {
+	dst = &model.User{}
+	dst.Name = src.Name
+	dst.Status = convertDomainStatusToModelStatus(src.Status)
+	if src.Roles != nil {
+		dst.Roles = make([]model.Role, len(src.Roles))
+		for i, e := range src.Roles {
+			dst.Roles[i] = convertDomainRoleToModelRole(e)
+		}
+	}
+	dst.Role = convertDomainRoleToModelRole(src.Role)
+
+	return
+}
+
+func ModelToDomain(src *model.User) (dst *domain.User) {
+	dst = &domain.User{}
+	dst.Name = src.Name
+	dst.Status = convertModelStatusToDomainStatus(src.Status)
+	// skip: dst.Roles
+	// skip: dst.Role
+
+	return
+}
+
+// convertDomainStatusToModelStatus converts domain.Status into model.Status.
+func convertDomainStatusToModelStatus(src domain.Status) (dst model.Status) {
+	switch src {
+	case domain.StatusActive:
+		dst = model.Active
+	case domain.StatusInactive:
+		dst = model.Inactive
+	case domain.StatusBanned:
+		dst = model.Banned
+	}
+
+	return
+}
+
+// convertDomainRoleToModelRole converts domain.Role into model.Role.
+func convertDomainRoleToModelRole(src domain.Role) (dst model.Role) {
+	switch src {
+	case domain.RoleAdmin:
+		dst = model.AdminRole
+	case domain.RoleMember:
+		dst = model.MemberRole
+	}
+
+	return
+}
+
+// convertModelStatusToDomainStatus converts model.Status into domain.Status.
+func convertModelStatusToDomainStatus(src model.Status) (dst domain.Status) {
+	switch src {
+	case model.Inactive:
+		dst = domain.StatusInactive
+	case model.Active:
+		dst = domain.StatusActive
+	case model.Banned:
+		dst = domain.StatusBanned
+	}
+
+	return
+}
@@ -0,0 +1,21 @@
+//go:build convergen
+
+package enum
+
+import (
+	"github.com/reedom/convergen/v8/tests/fixtures/usecase/enum/domain"
+	"github.com/reedom/convergen/v8/tests/fixtures/usecase/enum/model"
+)
+
+// :enum domain.Status model.Status Status*
+//
+//go:generate go run github.com/reedom/convergen
+type Convergen interface {
+	// :enum domain.Role model.Role Role* *Role
+	DomainToModel(*domain.User) *model.User
+
+	// :enum model.Status domain.Status * Status*
+	// :skip Roles
+	// :skip Role
+	ModelToDomain(*model.User) *domain.User
+}
@@ -0,0 +1,61 @@
+// Code generated by github.com/reedom/convergen
+// DO NOT EDIT.
+
+package flatten
+
+type Address struct {
+	Street string
+	City   string
+	Zip    int
+}
+
+type Geo struct {
+	Lat float64
+	Lng float64
+}
+
+type User struct {
+	Name     string
+	Address  Address
+	Location *Geo
+}
+
+type UserRow struct {
+	Name        string
+	AddressCity string
+	AddressZip  int64
+	GeoLat      float64
+	GeoLng      float64
+}
+
+type UserView struct {
+	Name     string
+	Address  *Address
+	Location Geo
+}
+
+func FromRow(src *UserRow) (dst *UserView) {
+	dst = &UserView{}
+	dst.Name = src.Name
+	dst.Address = &Address{}
+	// skip: dst.Address.Street
+	dst.Address.City = src.AddressCity
+	dst.Address.Zip = int(src.AddressZip)
+	dst.Location.Lat = src.GeoLat
+	dst.Location.Lng = src.GeoLng
+
+	return
+}
+
+func ToRow(src *User) (dst *UserRow) {
+	dst = &UserRow{}
+	dst.Name = src.Name
+	dst.AddressCity = src.Address.City
+	dst.AddressZip = int64(src.Address.Zip)
+	if src.Location != nil {
+		dst.GeoLat = src.Location.Lat
+		dst.GeoLng = src.Location.Lng
+	}
+
+	return
+}
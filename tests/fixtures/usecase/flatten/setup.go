@@ -0,0 +1,48 @@
+//go:build convergen
+
+package flatten
+
+type Address struct {
+	Street string
+	City   string
+	Zip    int
+}
+
+type Geo struct {
+	Lat float64
+	Lng float64
+}
+
+type User struct {
+	Name     string
+	Address  Address
+	Location *Geo
+}
+
+type UserRow struct {
+	Name        string
+	AddressCity string
+	AddressZip  int64
+	GeoLat      float64
+	GeoLng      float64
+}
+
+type UserView struct {
+	Name     string
+	Address  *Address
+	Location Geo
+}
+
+//go:generate go run github.com/reedom/convergen
+type Convergen interface {
+	// :typecast
+	// :flatten Address
+	// :flatten Location Geo
+	ToRow(*User) *UserRow
+
+	// :typecast
+	// :unflatten Address
+	// :unflatten Location Geo
+	// :skip Address.Street
+	FromRow(*UserRow) *UserView
+}
@@ -0,0 +1,19 @@
+package api
+
+type Pet struct {
+	ID   int64
+	Name string
+}
+
+type Page[T any] struct {
+	Items []T
+	Total int
+	Next  Cursor[string]
+	Marks []Cursor[int]
+	First [1]Cursor[T]
+	Pages int
+}
+
+type Cursor[K any] struct {
+	Key K
+}
@@ -0,0 +1,22 @@
+package domain
+
+type Pet struct {
+	ID   int64
+	Name string
+}
+
+type Page[T any] struct {
+	Items []T
+	Total int
+	Next  Cursor[string]
+	Marks []Cursor[int]
+	First [1]Cursor[T]
+}
+
+type Cursor[K any] struct {
+	Key K
+}
+
+func (p Page[T]) Pages() int {
+	return (p.Total + len(p.Items) - 1) / max(len(p.Items), 1)
+}
@@ -0,0 +1,76 @@
+// Code generated by github.com/reedom/convergen
+// DO NOT EDIT.
+
+package generics
+
+import (
+	"github.com/reedom/convergen/v8/tests/fixtures/usecase/generics/api"
+	"github.com/reedom/convergen/v8/tests/fixtures/usecase/generics/domain"
+)
+
+type PetPage = domain.Page[domain.Pet]
+
+func CursorFromDTO(src api.Cursor[string]) (dst domain.Cursor[string]) {
+	dst.Key = src.Key
+
+	return
+}
+
+func PageToDTO(src *domain.Page[domain.Pet]) (dst *api.Page[api.Pet]) {
+	dst = &api.Page[api.Pet]{}
+	if src.Items != nil {
+		dst.Items = make([]api.Pet, len(src.Items))
+		for i, e := range src.Items {
+			dst.Items[i] = *copyDomainPetToApiPet(&e)
+		}
+	}
+	dst.Total = src.Total
+	dst.Next.Key = src.Next.Key
+	if src.Marks != nil {
+		dst.Marks = make([]api.Cursor[int], len(src.Marks))
+		for i, e := range src.Marks {
+			dst.Marks[i] = *copyDomainCursorIntToApiCursorInt(&e)
+		}
+	}
+	for i, e := range src.First {
+		dst.First[i] = *copyDomainCursorDomainPetToApiCursorApiPet(&e)
+	}
+	dst.Pages = src.Pages()
+
+	return
+}
+
+// copyDomainPetToApiPet copies domain.Pet into api.Pet.
+func copyDomainPetToApiPet(src *domain.Pet) (dst *api.Pet) {
+	if src == nil {
+		return
+	}
+	dst = &api.Pet{}
+	dst.ID = src.ID
+	dst.Name = src.Name
+
+	return
+}
+
+// copyDomainCursorIntToApiCursorInt copies domain.Cursor[int] into api.Cursor[int].
+func copyDomainCursorIntToApiCursorInt(src *domain.Cursor[int]) (dst *api.Cursor[int]) {
+	if src == nil {
+		return
+	}
+	dst = &api.Cursor[int]{}
+	dst.Key = src.Key
+
+	return
+}
+
+// copyDomainCursorDomainPetToApiCursorApiPet copies domain.Cursor[domain.Pet] into api.Cursor[api.Pet].
+func copyDomainCursorDomainPetToApiCursorApiPet(src *domain.Cursor[domain.Pet]) (dst *api.Cursor[api.Pet]) {
+	if src == nil {
+		return
+	}
+	dst = &api.Cursor[api.Pet]{}
+	dst.Key.ID = src.Key.ID
+	dst.Key.Name = src.Key.Name
+
+	return
+}
@@ -0,0 +1,18 @@
+//go:build convergen
+
+package generics
+
+import (
+	"github.com/reedom/convergen/v8/tests/fixtures/usecase/generics/api"
+	"github.com/reedom/convergen/v8/tests/fixtures/usecase/generics/domain"
+)
+
+type PetPage = domain.Page[domain.Pet]
+
+//go:generate go run github.com/reedom/convergen
+type Convergen interface {
+	// :getter
+	PageToDTO(*PetPage) *api.Page[api.Pet]
+
+	CursorFromDTO(api.Cursor[string]) domain.Cursor[string]
+}
@@ -0,0 +1,68 @@
+// Code generated by github.com/reedom/convergen
+// DO NOT EDIT.
+
+package mapfield
+
+type SrcModel struct {
+	Labels map[string]string
+	Scores map[string]int32
+	Tags   map[Key]Status
+	Names  map[int]Name
+	Data   map[string]Data
+}
+
+type DstModel struct {
+	Labels map[string]string
+	Scores map[string]int64
+	Tags   map[string]int
+	Names  map[int]string
+	Data   map[string]Data
+}
+
+type Key string
+
+type Status int
+
+type Name string
+
+func (n Name) String() string {
+	return string(n)
+}
+
+type Data struct{}
+
+func Copy(src *SrcModel) (dst *DstModel) {
+	dst = &DstModel{}
+	if src.Labels != nil {
+		dst.Labels = make(map[string]string, len(src.Labels))
+		for k, v := range src.Labels {
+			dst.Labels[k] = v
+		}
+	}
+	if src.Scores != nil {
+		dst.Scores = make(map[string]int64, len(src.Scores))
+		for k, v := range src.Scores {
+			dst.Scores[k] = int64(v)
+		}
+	}
+	if src.Tags != nil {
+		dst.Tags = make(map[string]int, len(src.Tags))
+		for k, v := range src.Tags {
+			dst.Tags[string(k)] = int(v)
+		}
+	}
+	if src.Names != nil {
+		dst.Names = make(map[int]string, len(src.Names))
+		for k, v := range src.Names {
+			dst.Names[k] = v.String()
+		}
+	}
+	if src.Data != nil {
+		dst.Data = make(map[string]Data, len(src.Data))
+		for k, v := range src.Data {
+			dst.Data[k] = v
+		}
+	}
+
+	return
+}
@@ -0,0 +1,38 @@
+//go:build convergen
+
+package mapfield
+
+type SrcModel struct {
+	Labels map[string]string
+	Scores map[string]int32
+	Tags   map[Key]Status
+	Names  map[int]Name
+	Data   map[string]Data
+}
+
+type DstModel struct {
+	Labels map[string]string
+	Scores map[string]int64
+	Tags   map[string]int
+	Names  map[int]string
+	Data   map[string]Data
+}
+
+type Key string
+
+type Status int
+
+type Name string
+
+func (n Name) String() string {
+	return string(n)
+}
+
+type Data struct{}
+
+//go:generate go run github.com/reedom/convergen
+type Convergen interface {
+	// :typecast
+	// :stringer
+	Copy(*SrcModel) *DstModel
+}
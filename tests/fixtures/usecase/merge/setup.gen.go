@@ -0,0 +1,49 @@
+// Code generated by github.com/reedom/convergen
+// DO NOT EDIT.
+
+package merge
+
+type User struct {
+	ID   int64
+	Name string
+}
+
+type Profile struct {
+	Name string
+	Bio  string
+}
+
+type Settings struct {
+	Theme  string
+	Locale string
+}
+
+type UserView struct {
+	ID     int64
+	Name   string
+	Bio    string
+	Theme  string
+	Locale string
+}
+
+func Assemble(src *User, arg0 *Profile, arg1 *Settings) (dst *UserView) {
+	dst = &UserView{}
+	dst.ID = src.ID
+	dst.Name = src.Name
+	dst.Bio = arg0.Bio
+	dst.Theme = arg1.Theme
+	dst.Locale = arg1.Locale
+
+	return
+}
+
+func FromUser(src *User, arg0 *Profile) (dst *UserView) {
+	dst = &UserView{}
+	dst.ID = src.ID
+	dst.Name = src.Name
+	dst.Bio = arg0.Bio
+	// no match: dst.Theme
+	// no match: dst.Locale
+
+	return
+}
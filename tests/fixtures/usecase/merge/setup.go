@@ -0,0 +1,37 @@
+//go:build convergen
+
+package merge
+
+type User struct {
+	ID   int64
+	Name string
+}
+
+type Profile struct {
+	Name string
+	Bio  string
+}
+
+type Settings struct {
+	Theme  string
+	Locale string
+}
+
+type UserView struct {
+	ID     int64
+	Name   string
+	Bio    string
+	Theme  string
+	Locale string
+}
+
+// :merge
+//
+//go:generate go run github.com/reedom/convergen
+type Convergen interface {
+	Assemble(*User, *Profile, *Settings) *UserView
+
+	// :merge:off
+	// :map $2.Bio Bio
+	FromUser(*User, *Profile) *UserView
+}
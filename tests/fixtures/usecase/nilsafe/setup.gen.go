@@ -0,0 +1,66 @@
+// Code generated by github.com/reedom/convergen
+// DO NOT EDIT.
+
+package nilsafe
+
+import (
+	"strings"
+)
+
+type Pet struct {
+	Name  string
+	Owner *Owner
+}
+
+type Owner struct {
+	Name    string
+	Address *Address
+}
+
+func (o *Owner) Home() *Address {
+	return o.Address
+}
+
+type Address struct {
+	City    string
+	Country string
+}
+
+type PetSummary struct {
+	Name      string
+	OwnerName string
+	City      string
+	Country   string
+}
+
+func Summarize(src *Pet) (dst *PetSummary) {
+	dst = &PetSummary{}
+	dst.Name = src.Name
+	if src.Owner != nil {
+		dst.OwnerName = src.Owner.Name
+	}
+	if src.Owner != nil && src.Owner.Address != nil {
+		dst.City = toCityName(src.Owner.Address.City)
+	} else {
+		dst.City = "unknown"
+	}
+	if src.Owner != nil && src.Owner.Home() != nil {
+		dst.Country = src.Owner.Home().Country
+	}
+
+	return
+}
+
+func SummarizeUnsafe(src *Pet) (dst *PetSummary) {
+	dst = &PetSummary{}
+	dst.Name = src.Name
+	dst.OwnerName = src.Owner.Name
+	// no match: dst.City
+	// no match: dst.Country
+
+	return
+}
+
+func toCityName(s string) string {
+	return strings.ToUpper(s)
+}
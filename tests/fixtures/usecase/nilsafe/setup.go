@@ -0,0 +1,53 @@
+//go:build convergen
+
+package nilsafe
+
+import (
+	"strings"
+)
+
+type Pet struct {
+	Name  string
+	Owner *Owner
+}
+
+type Owner struct {
+	Name    string
+	Address *Address
+}
+
+func (o *Owner) Home() *Address {
+	return o.Address
+}
+
+type Address struct {
+	City    string
+	Country string
+}
+
+type PetSummary struct {
+	Name      string
+	OwnerName string
+	City      string
+	Country   string
+}
+
+// :nilsafe
+//
+//go:generate go run github.com/reedom/convergen
+type Convergen interface {
+	// :getter
+	// :map Owner.Name OwnerName
+	// :conv toCityName Owner.Address.City City
+	// :map Owner.Home().Country Country
+	// :fallback City "unknown"
+	Summarize(*Pet) *PetSummary
+
+	// :nilsafe:off
+	// :map Owner.Name OwnerName
+	SummarizeUnsafe(*Pet) *PetSummary
+}
+
+func toCityName(s string) string {
+	return strings.ToUpper(s)
+}
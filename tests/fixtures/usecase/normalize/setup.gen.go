@@ -0,0 +1,46 @@
+// Code generated by github.com/reedom/convergen
+// DO NOT EDIT.
+
+package normalize
+
+type User struct {
+	UserID    int64
+	URL       string
+	Pk_id     int64
+	DbName    string
+	EmailCol  string
+	CreatedAt string
+}
+
+type UserModel struct {
+	UserId    int64
+	Url       string
+	PkID      int64
+	Name      string
+	Email     string
+	CreatedAt string
+}
+
+func FromModel(src *UserModel) (dst *User) {
+	dst = &User{}
+	dst.UserID = src.UserId
+	dst.URL = src.Url
+	dst.Pk_id = src.PkID
+	// no match: dst.DbName
+	// no match: dst.EmailCol
+	dst.CreatedAt = src.CreatedAt
+
+	return
+}
+
+func ToModel(src *User) (dst *UserModel) {
+	dst = &UserModel{}
+	dst.UserId = src.UserID
+	dst.Url = src.URL
+	dst.PkID = src.Pk_id
+	dst.Name = src.DbName
+	dst.Email = src.EmailCol
+	dst.CreatedAt = src.CreatedAt
+
+	return
+}
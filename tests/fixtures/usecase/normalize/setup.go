@@ -0,0 +1,31 @@
+//go:build convergen
+
+package normalize
+
+type User struct {
+	UserID    int64
+	URL       string
+	Pk_id     int64
+	DbName    string
+	EmailCol  string
+	CreatedAt string
+}
+
+type UserModel struct {
+	UserId    int64
+	Url       string
+	PkID      int64
+	Name      string
+	Email     string
+	CreatedAt string
+}
+
+// :normalize snake acronym
+//
+//go:generate go run github.com/reedom/convergen
+type Convergen interface {
+	// :normalize Db* *Col
+	ToModel(*User) *UserModel
+
+	FromModel(*UserModel) *User
+}
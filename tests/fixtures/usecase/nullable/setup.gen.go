@@ -0,0 +1,110 @@
+// Code generated by github.com/reedom/convergen
+// DO NOT EDIT.
+
+package nullable
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/reedom/convergen/v8/pkg/stdconv"
+)
+
+type Row struct {
+	Name      sql.NullString
+	Nickname  sql.NullString
+	Age       sql.NullInt32
+	CreatedAt sql.NullTime
+	Score     sql.Null[float64]
+	Tags      []sql.NullString
+}
+
+type User struct {
+	Name      string
+	Nickname  *string
+	Age       int64
+	CreatedAt time.Time
+	Score     *float64
+	Tags      []string
+}
+
+type Input struct {
+	Name      string
+	Nickname  *string
+	Age       int32
+	CreatedAt *time.Time
+	Score     float64
+	Tags      []string
+}
+
+func InputToRow(src *Input) (dst *Row) {
+	dst = &Row{}
+	dst.Name = sql.NullString{String: src.Name, Valid: true}
+	if src.Nickname != nil {
+		dst.Nickname = sql.NullString{String: *src.Nickname, Valid: true}
+	}
+	dst.Age = sql.NullInt32{Int32: src.Age, Valid: true}
+	if src.CreatedAt != nil {
+		dst.CreatedAt = sql.NullTime{Time: *src.CreatedAt, Valid: true}
+	}
+	dst.Score = sql.Null[float64]{V: src.Score, Valid: true}
+	if src.Tags != nil {
+		dst.Tags = make([]sql.NullString, len(src.Tags))
+		for i, e := range src.Tags {
+			dst.Tags[i] = sql.NullString{String: e, Valid: true}
+		}
+	}
+
+	return
+}
+
+func RowToUser(src *Row) (dst *User) {
+	dst = &User{}
+	if src.Name.Valid {
+		dst.Name = src.Name.String
+	}
+	if src.Nickname.Valid {
+		dst.Nickname = stdconv.Ptr(src.Nickname.String)
+	}
+	if src.Age.Valid {
+		dst.Age = int64(src.Age.Int32)
+	}
+	if src.CreatedAt.Valid {
+		dst.CreatedAt = src.CreatedAt.Time
+	}
+	if src.Score.Valid {
+		dst.Score = stdconv.Ptr(src.Score.V)
+	}
+	// skip: dst.Tags
+
+	return
+}
+
+func UpdateUser(dst *User, src *Row) {
+	if src.Name.Valid {
+		dst.Name = src.Name.String
+	} else {
+		dst.Name = ""
+	}
+	if src.Nickname.Valid {
+		dst.Nickname = stdconv.Ptr(src.Nickname.String)
+	} else {
+		dst.Nickname = nil
+	}
+	if src.Age.Valid {
+		dst.Age = int64(src.Age.Int32)
+	} else {
+		dst.Age = 0
+	}
+	if src.CreatedAt.Valid {
+		dst.CreatedAt = src.CreatedAt.Time
+	} else {
+		dst.CreatedAt = time.Time{}
+	}
+	if src.Score.Valid {
+		dst.Score = stdconv.Ptr(src.Score.V)
+	} else {
+		dst.Score = nil
+	}
+	// skip: dst.Tags
+}
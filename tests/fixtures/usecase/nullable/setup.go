@@ -0,0 +1,50 @@
+//go:build convergen
+
+package nullable
+
+import (
+	"database/sql"
+	"time"
+)
+
+type Row struct {
+	Name      sql.NullString
+	Nickname  sql.NullString
+	Age       sql.NullInt32
+	CreatedAt sql.NullTime
+	Score     sql.Null[float64]
+	Tags      []sql.NullString
+}
+
+type User struct {
+	Name      string
+	Nickname  *string
+	Age       int64
+	CreatedAt time.Time
+	Score     *float64
+	Tags      []string
+}
+
+type Input struct {
+	Name      string
+	Nickname  *string
+	Age       int32
+	CreatedAt *time.Time
+	Score     float64
+	Tags      []string
+}
+
+//go:generate go run github.com/reedom/convergen
+type Convergen interface {
+	// :typecast
+	// :skip Tags
+	RowToUser(*Row) *User
+
+	// :style arg
+	// :typecast
+	// :skip Tags
+	UpdateUser(*Row) *User
+
+	// :typecast
+	InputToRow(*Input) *Row
+}
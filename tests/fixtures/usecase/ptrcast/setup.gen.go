@@ -0,0 +1,152 @@
+// Code generated by github.com/reedom/convergen
+// DO NOT EDIT.
+
+package ptrcast
+
+import (
+	"errors"
+
+	"github.com/reedom/convergen/v8/pkg/stdconv"
+)
+
+type Request struct {
+	Name     *string
+	Age      *int32
+	Nickname string
+	Score    int32
+	Status   Status
+	Rank     *int
+	Owner    *Owner
+	Tags     []string
+}
+
+type User struct {
+	Name     string
+	Age      int64
+	Nickname *string
+	Score    *int64
+	Status   string
+	Rank     *int64
+	Owner    Owner
+	Tags     []*string
+}
+
+type Owner struct {
+	Name string
+}
+
+type Status int
+
+func (s Status) String() string {
+	return [...]string{"active", "inactive"}[s]
+}
+
+func FromRequest(src *Request) (dst *User) {
+	dst = &User{}
+	if src.Name != nil {
+		dst.Name = *src.Name
+	}
+	if src.Age != nil {
+		dst.Age = int64(*src.Age)
+	}
+	dst.Nickname = stdconv.Ptr(src.Nickname)
+	dst.Score = stdconv.Ptr(int64(src.Score))
+	dst.Status = src.Status.String()
+	if src.Rank != nil {
+		dst.Rank = stdconv.Ptr(int64(*src.Rank))
+	}
+	if src.Owner != nil {
+		dst.Owner = *src.Owner
+	}
+	if src.Tags != nil {
+		dst.Tags = make([]*string, len(src.Tags))
+		for i, e := range src.Tags {
+			dst.Tags[i] = stdconv.Ptr(e)
+		}
+	}
+
+	return
+}
+
+func FromRequestInto(dst *User, src *Request) {
+	if src.Name != nil {
+		dst.Name = *src.Name
+	} else {
+		dst.Name = ""
+	}
+	if src.Age != nil {
+		dst.Age = int64(*src.Age)
+	} else {
+		dst.Age = 0
+	}
+	dst.Nickname = stdconv.Ptr(src.Nickname)
+	dst.Score = stdconv.Ptr(int64(src.Score))
+	dst.Status = src.Status.String()
+	if src.Rank != nil {
+		dst.Rank = stdconv.Ptr(int64(*src.Rank))
+	} else {
+		dst.Rank = nil
+	}
+	if src.Owner != nil {
+		dst.Owner = *src.Owner
+	} else {
+		dst.Owner = Owner{}
+	}
+	// skip: dst.Tags
+}
+
+func FromRequestStrict(src *Request) (dst *User, err error) {
+	dst = &User{}
+	if src.Name != nil {
+		dst.Name = *src.Name
+	} else {
+		err = errors.New("src.Name is nil")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if src.Age != nil {
+		dst.Age = int64(*src.Age)
+	} else {
+		err = errors.New("src.Age is nil")
+	}
+	if err != nil {
+		return nil, err
+	}
+	dst.Nickname = stdconv.Ptr(src.Nickname)
+	dst.Score = stdconv.Ptr(int64(src.Score))
+	dst.Status = src.Status.String()
+	if src.Rank != nil {
+		dst.Rank = stdconv.Ptr(int64(*src.Rank))
+	}
+	if src.Owner != nil {
+		dst.Owner = *src.Owner
+	} else {
+		err = errors.New("src.Owner is nil")
+	}
+	if err != nil {
+		return nil, err
+	}
+	// skip: dst.Tags
+
+	return
+}
+
+func MergeRequest(dst *User, src *Request) {
+	if src.Name != nil {
+		dst.Name = *src.Name
+	}
+	if src.Age != nil {
+		dst.Age = int64(*src.Age)
+	}
+	dst.Nickname = stdconv.Ptr(src.Nickname)
+	dst.Score = stdconv.Ptr(int64(src.Score))
+	dst.Status = src.Status.String()
+	if src.Rank != nil {
+		dst.Rank = stdconv.Ptr(int64(*src.Rank))
+	}
+	if src.Owner != nil {
+		dst.Owner = *src.Owner
+	}
+	// skip: dst.Tags
+}
@@ -0,0 +1,63 @@
+//go:build convergen
+
+package ptrcast
+
+type Request struct {
+	Name     *string
+	Age      *int32
+	Nickname string
+	Score    int32
+	Status   Status
+	Rank     *int
+	Owner    *Owner
+	Tags     []string
+}
+
+type User struct {
+	Name     string
+	Age      int64
+	Nickname *string
+	Score    *int64
+	Status   string
+	Rank     *int64
+	Owner    Owner
+	Tags     []*string
+}
+
+type Owner struct {
+	Name string
+}
+
+type Status int
+
+func (s Status) String() string {
+	return [...]string{"active", "inactive"}[s]
+}
+
+//go:generate go run github.com/reedom/convergen
+type Convergen interface {
+	// :ptrcast
+	// :typecast
+	// :stringer
+	FromRequest(*Request) *User
+
+	// :style arg
+	// :ptrcast zero
+	// :typecast
+	// :stringer
+	// :skip Tags
+	FromRequestInto(*Request) *User
+
+	// :ptrcast skip
+	// :typecast
+	// :stringer
+	// :skip Tags
+	// :style arg
+	MergeRequest(*Request) *User
+
+	// :ptrcast error
+	// :typecast
+	// :stringer
+	// :skip Tags
+	FromRequestStrict(*Request) (*User, error)
+}
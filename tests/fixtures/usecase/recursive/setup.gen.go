@@ -0,0 +1,120 @@
+// Code generated by github.com/reedom/convergen
+// DO NOT EDIT.
+
+package recursive
+
+type Node struct {
+	Name     string
+	Children []*Node
+}
+
+type NodeModel struct {
+	Name     string
+	Children []*NodeModel
+}
+
+type Item struct {
+	Value int
+	Next  *Item
+}
+
+type ItemModel struct {
+	Value int64
+	Next  *ItemModel
+}
+
+type Category struct {
+	ID     int
+	Name   string
+	Parent *Category
+	Meta   Meta
+}
+
+type Meta struct {
+	Related *Category
+}
+
+type CategoryModel struct {
+	ID     int
+	Name   string
+	Parent *CategoryModel
+	Meta   MetaModel
+}
+
+type MetaModel struct {
+	Related *CategoryModel
+}
+
+func ToCategoryModel(src *Category) (dst *CategoryModel) {
+	dst = &CategoryModel{}
+	dst.ID = src.ID
+	dst.Name = src.Name
+	dst.Parent = copyCategoryToCategoryModel(src.Parent)
+	dst.Meta.Related = copyCategoryToCategoryModel(src.Meta.Related)
+
+	return
+}
+
+func ToItemModel(src *Item) (dst *ItemModel) {
+	dst = &ItemModel{}
+	dst.Value = int64(src.Value)
+	dst.Next = copyItemToItemModel(src.Next)
+
+	return
+}
+
+func ToNodeModel(src *Node) (dst *NodeModel) {
+	dst = &NodeModel{}
+	dst.Name = src.Name
+	if src.Children != nil {
+		dst.Children = make([]*NodeModel, len(src.Children))
+		for i, e := range src.Children {
+			dst.Children[i] = copyNodeToNodeModel(e)
+		}
+	}
+
+	return
+}
+
+// copyCategoryToCategoryModel copies Category into CategoryModel.
+func copyCategoryToCategoryModel(src *Category) (dst *CategoryModel) {
+	if src == nil {
+		return
+	}
+	dst = &CategoryModel{}
+	dst.ID = src.ID
+	dst.Name = src.Name
+	dst.Parent = copyCategoryToCategoryModel(src.Parent)
+	dst.Meta.Related = copyCategoryToCategoryModel(src.Meta.Related)
+
+	return
+}
+
+// copyItemToItemModel copies Item into ItemModel.
+func copyItemToItemModel(src *Item) (dst *ItemModel) {
+	if src == nil {
+		return
+	}
+	dst = &ItemModel{}
+	dst.Value = int64(src.Value)
+	dst.Next = copyItemToItemModel(src.Next)
+
+	return
+}
+
+// copyNodeToNodeModel copies Node into NodeModel.
+func copyNodeToNodeModel(src *Node) (dst *NodeModel) {
+	if src == nil {
+		return
+	}
+	dst = &NodeModel{}
+	dst.Name = src.Name
+	if src.Children != nil {
+		dst.Children = make([]*NodeModel, len(src.Children))
+		for i, e := range src.Children {
+			dst.Children[i] = copyNodeToNodeModel(e)
+		}
+	}
+
+	return
+}
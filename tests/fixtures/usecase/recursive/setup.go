@@ -0,0 +1,55 @@
+//go:build convergen
+
+package recursive
+
+type Node struct {
+	Name     string
+	Children []*Node
+}
+
+type NodeModel struct {
+	Name     string
+	Children []*NodeModel
+}
+
+type Item struct {
+	Value int
+	Next  *Item
+}
+
+type ItemModel struct {
+	Value int64
+	Next  *ItemModel
+}
+
+type Category struct {
+	ID     int
+	Name   string
+	Parent *Category
+	Meta   Meta
+}
+
+type Meta struct {
+	Related *Category
+}
+
+type CategoryModel struct {
+	ID     int
+	Name   string
+	Parent *CategoryModel
+	Meta   MetaModel
+}
+
+type MetaModel struct {
+	Related *CategoryModel
+}
+
+//go:generate go run github.com/reedom/convergen
+type Convergen interface {
+	ToNodeModel(*Node) *NodeModel
+
+	// :typecast
+	ToItemModel(*Item) *ItemModel
+
+	ToCategoryModel(*Category) *CategoryModel
+}
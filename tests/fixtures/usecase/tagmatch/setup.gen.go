@@ -0,0 +1,52 @@
+// Code generated by github.com/reedom/convergen
+// DO NOT EDIT.
+
+package tagmatch
+
+type UserDTO struct {
+	UserIdentifier int64   `json:"id"`
+	FullName       string  `json:"name,omitempty"`
+	Mail           string  `json:"email"`
+	Address        AddrDTO `json:"address"`
+	Internal       string  `json:"-"`
+}
+
+type AddrDTO struct {
+	CityName string `json:"city"`
+}
+
+type User struct {
+	ID       int64   `json:"id" db:"user_id"`
+	Name     string  `json:"name" db:"user_name"`
+	Email    string  `json:"email"`
+	Address  Address `json:"address"`
+	Internal string
+}
+
+type Address struct {
+	City string `json:"city"`
+}
+
+type UserRow struct {
+	UserID   int64  `db:"user_id"`
+	UserName string `db:"user_name"`
+}
+
+func FromDTO(src *UserDTO) (dst *User) {
+	dst = &User{}
+	dst.ID = src.UserIdentifier
+	dst.Name = src.FullName
+	dst.Email = src.Mail
+	dst.Address.City = src.Address.CityName
+	// no match: dst.Internal
+
+	return
+}
+
+func ToRow(src *User) (dst *UserRow) {
+	dst = &UserRow{}
+	dst.UserID = src.ID
+	dst.UserName = src.Name
+
+	return
+}
@@ -0,0 +1,41 @@
+//go:build convergen
+
+package tagmatch
+
+type UserDTO struct {
+	UserIdentifier int64   `json:"id"`
+	FullName       string  `json:"name,omitempty"`
+	Mail           string  `json:"email"`
+	Address        AddrDTO `json:"address"`
+	Internal       string  `json:"-"`
+}
+
+type AddrDTO struct {
+	CityName string `json:"city"`
+}
+
+type User struct {
+	ID       int64   `json:"id" db:"user_id"`
+	Name     string  `json:"name" db:"user_name"`
+	Email    string  `json:"email"`
+	Address  Address `json:"address"`
+	Internal string
+}
+
+type Address struct {
+	City string `json:"city"`
+}
+
+type UserRow struct {
+	UserID   int64  `db:"user_id"`
+	UserName string `db:"user_name"`
+}
+
+//go:generate go run github.com/reedom/convergen
+type Convergen interface {
+	// :match tag json
+	FromDTO(*UserDTO) *User
+
+	// :match tag db
+	ToRow(*User) *UserRow
+}
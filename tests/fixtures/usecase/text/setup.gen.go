@@ -0,0 +1,124 @@
+// Code generated by github.com/reedom/convergen
+// DO NOT EDIT.
+
+package text
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/reedom/convergen/v8/pkg/stdconv"
+)
+
+type Level int
+
+func ParseLevel(s string) (Level, error) {
+	switch s {
+	case "low":
+		return 0, nil
+	case "high":
+		return 1, nil
+	}
+	return 0, fmt.Errorf("unknown level %q", s)
+}
+
+func (l Level) MarshalText() ([]byte, error) {
+	if l == 0 {
+		return []byte("low"), nil
+	}
+	return []byte("high"), nil
+}
+
+type Tag string
+
+func ParseTag(s string) Tag {
+	return Tag(strings.ToLower(s))
+}
+
+type Color struct {
+	R, G, B uint8
+}
+
+func (c Color) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)), nil
+}
+
+func (c *Color) UnmarshalText(text []byte) error {
+	_, err := fmt.Sscanf(string(text), "#%02x%02x%02x", &c.R, &c.G, &c.B)
+	return err
+}
+
+type Setting struct {
+	Level   Level
+	Tag     Tag
+	Color   Color
+	Addr    netip.Addr
+	Updated time.Time
+}
+
+type SettingModel struct {
+	Level   string
+	Tag     string
+	Color   string
+	Addr    string
+	Updated string
+}
+
+func FromModel(src *SettingModel) (dst *Setting, err error) {
+	dst = &Setting{}
+	dst.Level, err = ParseLevel(src.Level)
+	if err != nil {
+		return nil, err
+	}
+	dst.Tag = ParseTag(src.Tag)
+	dst.Color, err = stdconv.UnmarshalText[Color](src.Color)
+	if err != nil {
+		return nil, err
+	}
+	dst.Addr, err = netip.ParseAddr(src.Addr)
+	if err != nil {
+		return nil, err
+	}
+	dst.Updated, err = stdconv.UnmarshalText[time.Time](src.Updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func TagFromModel(src *SettingModel) (dst *Setting) {
+	dst = &Setting{}
+	// skip: dst.Level
+	dst.Tag = ParseTag(src.Tag)
+	// skip: dst.Color
+	// skip: dst.Addr
+	// skip: dst.Updated
+
+	return
+}
+
+func ToModel(src *Setting) (dst *SettingModel, err error) {
+	dst = &SettingModel{}
+	dst.Level, err = stdconv.MarshalText(src.Level)
+	if err != nil {
+		return nil, err
+	}
+	dst.Tag = string(src.Tag)
+	dst.Color, err = stdconv.MarshalText(src.Color)
+	if err != nil {
+		return nil, err
+	}
+	dst.Addr, err = stdconv.MarshalText(src.Addr)
+	if err != nil {
+		return nil, err
+	}
+	dst.Updated, err = stdconv.MarshalText(src.Updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
@@ -0,0 +1,79 @@
+//go:build convergen
+
+package text
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+	"time"
+)
+
+type Level int
+
+func ParseLevel(s string) (Level, error) {
+	switch s {
+	case "low":
+		return 0, nil
+	case "high":
+		return 1, nil
+	}
+	return 0, fmt.Errorf("unknown level %q", s)
+}
+
+func (l Level) MarshalText() ([]byte, error) {
+	if l == 0 {
+		return []byte("low"), nil
+	}
+	return []byte("high"), nil
+}
+
+type Tag string
+
+func ParseTag(s string) Tag {
+	return Tag(strings.ToLower(s))
+}
+
+type Color struct {
+	R, G, B uint8
+}
+
+func (c Color) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)), nil
+}
+
+func (c *Color) UnmarshalText(text []byte) error {
+	_, err := fmt.Sscanf(string(text), "#%02x%02x%02x", &c.R, &c.G, &c.B)
+	return err
+}
+
+type Setting struct {
+	Level   Level
+	Tag     Tag
+	Color   Color
+	Addr    netip.Addr
+	Updated time.Time
+}
+
+type SettingModel struct {
+	Level   string
+	Tag     string
+	Color   string
+	Addr    string
+	Updated string
+}
+
+// :text
+//
+//go:generate go run github.com/reedom/convergen
+type Convergen interface {
+	// :typecast
+	ToModel(*Setting) (*SettingModel, error)
+	FromModel(*SettingModel) (*Setting, error)
+
+	// :skip Level
+	// :skip Color
+	// :skip Addr
+	// :skip Updated
+	TagFromModel(*SettingModel) *Setting
+}
@@ -0,0 +1,25 @@
+package domain
+
+type Payment interface {
+	Amount() int64
+}
+
+type Card struct {
+	Number string
+	Total  int64
+}
+
+func (c *Card) Amount() int64 { return c.Total }
+
+type Bank struct {
+	Account string
+	Total   int64
+}
+
+func (b Bank) Amount() int64 { return b.Total }
+
+type Order struct {
+	ID      int64
+	Payment Payment
+	Refunds []Payment
+}
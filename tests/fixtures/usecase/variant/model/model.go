@@ -0,0 +1,26 @@
+package model
+
+// Payment is a sealed union of the payment methods.
+type Payment interface {
+	isPayment()
+}
+
+type Card struct {
+	Number string
+	Total  int64
+}
+
+func (*Card) isPayment() {}
+
+type Bank struct {
+	Account string
+	Total   int64
+}
+
+func (*Bank) isPayment() {}
+
+type Order struct {
+	ID      int64
+	Payment Payment
+	Refunds []Payment
+}
@@ -0,0 +1,74 @@
+// Code generated by github.com/reedom/convergen
+// DO NOT EDIT.
+
+package variant
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/reedom/convergen/v8/tests/fixtures/usecase/variant/domain"
+	"github.com/reedom/convergen/v8/tests/fixtures/usecase/variant/model"
+)
+
+func CardToModel(src *domain.Card) (dst *model.Card) {
+	dst = &model.Card{}
+	dst.Number = src.Number
+	dst.Total = src.Total
+
+	return
+}
+
+func OrderToModel(src *domain.Order) (dst *model.Order, err error) {
+	dst = &model.Order{}
+	dst.ID = src.ID
+	dst.Payment, err = convertDomainPaymentToModelPayment(src.Payment)
+	if err != nil {
+		return nil, err
+	}
+	if src.Refunds != nil {
+		dst.Refunds = make([]model.Payment, len(src.Refunds))
+		for i, e := range src.Refunds {
+			dst.Refunds[i], err = convertDomainPaymentToModelPayment(e)
+			if err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// convertDomainPaymentToModelPayment converts domain.Payment into model.Payment by its concrete type.
+func convertDomainPaymentToModelPayment(src domain.Payment) (dst model.Payment, err error) {
+	switch v := src.(type) {
+	case nil:
+	case *domain.Card:
+		if v != nil {
+			r := CardToModel(v)
+			if r != nil {
+				dst = r
+			}
+		}
+	case domain.Bank:
+		var r *model.Bank
+		r, err = bankToModel(v)
+		if r != nil {
+			dst = r
+		}
+	default:
+		err = fmt.Errorf("cannot convert %T into model.Payment", src)
+	}
+
+	return
+}
+
+func bankToModel(bank domain.Bank) (*model.Bank, error) {
+	if bank.Account == "" {
+		return nil, errors.New("no account")
+	}
+	return &model.Bank{Account: bank.Account, Total: bank.Total}, nil
+}
@@ -0,0 +1,27 @@
+//go:build convergen
+
+package variant
+
+import (
+	"errors"
+
+	"github.com/reedom/convergen/v8/tests/fixtures/usecase/variant/domain"
+	"github.com/reedom/convergen/v8/tests/fixtures/usecase/variant/model"
+)
+
+// :variant CardToModel
+// :variant bankToModel
+//
+//go:generate go run github.com/reedom/convergen
+type Convergen interface {
+	OrderToModel(*domain.Order) (*model.Order, error)
+
+	CardToModel(*domain.Card) *model.Card
+}
+
+func bankToModel(bank domain.Bank) (*model.Bank, error) {
+	if bank.Account == "" {
+		return nil, errors.New("no account")
+	}
+	return &model.Bank{Account: bank.Account, Total: bank.Total}, nil
+}
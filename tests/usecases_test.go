@@ -5,10 +5,8 @@ import (
 	"os"
 	"testing"
 
-	"github.com/reedom/convergen/v8/pkg/generator"
-	"github.com/reedom/convergen/v8/pkg/generator/model"
-	"github.com/reedom/convergen/v8/pkg/logger"
-	"github.com/reedom/convergen/v8/pkg/parser"
+	"github.com/reedom/convergen/v8/pkg/config"
+	"github.com/reedom/convergen/v8/pkg/convergen"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -24,6 +22,18 @@ func TestUseCases(t *testing.T) {
 			source:   "fixtures/usecase/additionalargs/setup.go",
 			expected: "fixtures/usecase/additionalargs/setup.gen.go",
 		},
+		{
+			source:   "fixtures/usecase/arrays/setup.go",
+			expected: "fixtures/usecase/arrays/setup.gen.go",
+		},
+		{
+			source:   "fixtures/usecase/builtin/setup.go",
+			expected: "fixtures/usecase/builtin/setup.gen.go",
+		},
+		{
+			source:   "fixtures/usecase/checkedcast/setup.go",
+			expected: "fixtures/usecase/checkedcast/setup.gen.go",
+		},
 		{
 			source:   "fixtures/usecase/converter/setup.go",
 			expected: "fixtures/usecase/converter/setup.gen.go",
@@ -32,6 +42,38 @@ func TestUseCases(t *testing.T) {
 			source:   "fixtures/usecase/embedded/setup.go",
 			expected: "fixtures/usecase/embedded/setup.gen.go",
 		},
+		{
+			source:   "fixtures/usecase/convelem/setup.go",
+			expected: "fixtures/usecase/convelem/setup.gen.go",
+		},
+		{
+			source:   "fixtures/usecase/convtype/setup.go",
+			expected: "fixtures/usecase/convtype/setup.gen.go",
+		},
+		{
+			source:   "fixtures/usecase/convwith/setup.go",
+			expected: "fixtures/usecase/convwith/setup.gen.go",
+		},
+		{
+			source:   "fixtures/usecase/elemopts/setup.go",
+			expected: "fixtures/usecase/elemopts/setup.gen.go",
+		},
+		{
+			source:   "fixtures/usecase/elemstruct/setup.go",
+			expected: "fixtures/usecase/elemstruct/setup.gen.go",
+		},
+		{
+			source:   "fixtures/usecase/enum/setup.go",
+			expected: "fixtures/usecase/enum/setup.gen.go",
+		},
+		{
+			source:   "fixtures/usecase/flatten/setup.go",
+			expected: "fixtures/usecase/flatten/setup.gen.go",
+		},
+		{
+			source:   "fixtures/usecase/generics/setup.go",
+			expected: "fixtures/usecase/generics/setup.gen.go",
+		},
 		{
 			source:   "fixtures/usecase/getter/setup.go",
 			expected: "fixtures/usecase/getter/setup.gen.go",
@@ -40,10 +82,26 @@ func TestUseCases(t *testing.T) {
 			source:   "fixtures/usecase/literal/setup.go",
 			expected: "fixtures/usecase/literal/setup.gen.go",
 		},
+		{
+			source:   "fixtures/usecase/merge/setup.go",
+			expected: "fixtures/usecase/merge/setup.gen.go",
+		},
+		{
+			source:   "fixtures/usecase/nilsafe/setup.go",
+			expected: "fixtures/usecase/nilsafe/setup.gen.go",
+		},
+		{
+			source:   "fixtures/usecase/normalize/setup.go",
+			expected: "fixtures/usecase/normalize/setup.gen.go",
+		},
 		{
 			source:   "fixtures/usecase/nocase/setup.go",
 			expected: "fixtures/usecase/nocase/setup.gen.go",
 		},
+		{
+			source:   "fixtures/usecase/mapfield/setup.go",
+			expected: "fixtures/usecase/mapfield/setup.gen.go",
+		},
 		{
 			source:   "fixtures/usecase/mapname/setup.go",
 			expected: "fixtures/usecase/mapname/setup.gen.go",
@@ -52,14 +110,26 @@ func TestUseCases(t *testing.T) {
 			source:   "fixtures/usecase/multi_intf/setup.go",
 			expected: "fixtures/usecase/multi_intf/setup.gen.go",
 		},
+		{
+			source:   "fixtures/usecase/nullable/setup.go",
+			expected: "fixtures/usecase/nullable/setup.gen.go",
+		},
 		{
 			source:   "fixtures/usecase/postprocess/setup.go",
 			expected: "fixtures/usecase/postprocess/setup.gen.go",
 		},
+		{
+			source:   "fixtures/usecase/ptrcast/setup.go",
+			expected: "fixtures/usecase/ptrcast/setup.gen.go",
+		},
 		{
 			source:   "fixtures/usecase/ref/setup.go",
 			expected: "fixtures/usecase/ref/generated/setup.gen.go",
 		},
+		{
+			source:   "fixtures/usecase/recursive/setup.go",
+			expected: "fixtures/usecase/recursive/setup.gen.go",
+		},
 		{
 			source:   "fixtures/usecase/simple/setup.go",
 			expected: "fixtures/usecase/simple/setup.gen.go",
@@ -76,6 +146,14 @@ func TestUseCases(t *testing.T) {
 			source:   "fixtures/usecase/style/setup.go",
 			expected: "fixtures/usecase/style/setup.gen.go",
 		},
+		{
+			source:   "fixtures/usecase/tagmatch/setup.go",
+			expected: "fixtures/usecase/tagmatch/setup.gen.go",
+		},
+		{
+			source:   "fixtures/usecase/text/setup.go",
+			expected: "fixtures/usecase/text/setup.gen.go",
+		},
 		{
 			source:   "fixtures/usecase/typecast/setup.go",
 			expected: "fixtures/usecase/typecast/setup.gen.go",
@@ -84,10 +162,12 @@ func TestUseCases(t *testing.T) {
 			source:   "fixtures/usecase/maps/setup.go",
 			expected: "fixtures/usecase/maps/setup.gen.go",
 		},
+		{
+			source:   "fixtures/usecase/variant/setup.go",
+			expected: "fixtures/usecase/variant/setup.gen.go",
+		},
 	}
 
-	logger.SetupLogger(logger.ForTest())
-
 	for _, tt := range cases {
 		tt := tt
 		t.Run(tt.source, func(t *testing.T) {
@@ -95,36 +175,13 @@ func TestUseCases(t *testing.T) {
 			expected, err := os.ReadFile(tt.expected)
 			require.Nil(t, err)
 
-			//log.SetFlags(log.Llongfile)
-			//logger.SetupLogger(logger.Enable())
-
-			p, err := parser.NewParser(tt.source, tt.expected)
-			require.Nil(t, err)
-			methods, err := p.Parse()
-			require.Nil(t, err)
-
-			var funcBlocks []model.FunctionsBlock
-			builder := p.CreateBuilder()
-			for _, info := range methods {
-				functions, err := builder.CreateFunctions(info.Methods)
-				require.Nil(t, err)
-				block := model.FunctionsBlock{
-					Marker:    info.Marker,
-					Functions: functions,
-				}
-				funcBlocks = append(funcBlocks, block)
-			}
-
-			baseCode, err := p.GenerateBaseCode()
-			require.Nil(t, err)
-			code := model.Code{
-				BaseCode:       baseCode,
-				FunctionBlocks: funcBlocks,
-			}
-
-			g := generator.NewGenerator(code)
-			actual, err := g.Generate(tt.source, false, true)
+			result, err := convergen.Generate(convergen.Request{
+				Input:  tt.source,
+				Output: tt.expected,
+			})
 			require.Nil(t, err)
+			require.Len(t, result.Files, 1)
+			actual := result.Files[0].Code
 
 			if !assert.Equal(t, string(expected), string(actual)) {
 				fmt.Println("-----------[generated]------------")
@@ -133,3 +190,27 @@ func TestUseCases(t *testing.T) {
 		})
 	}
 }
+
+func TestPackagePatterns(t *testing.T) {
+	t.Parallel()
+
+	result, err := convergen.Generate(convergen.Request{
+		Patterns: []string{
+			"./fixtures/usecase/nointf",
+			"./fixtures/usecase/simple",
+			"./fixtures/usecase/text",
+			"./fixtures/usecase/variant/...",
+		},
+	})
+	require.Nil(t, err)
+	require.Len(t, result.Files, 3, "nointf has no convergen interface")
+
+	for _, file := range result.Files {
+		expected, err := os.ReadFile(config.OutputPath(file.Input))
+		require.Nil(t, err)
+		if !assert.Equal(t, string(expected), string(file.Code), file.Input) {
+			fmt.Println("-----------[generated]------------")
+			fmt.Println(string(file.Code))
+		}
+	}
+}